@@ -29,43 +29,52 @@ func (c *Client) CancelAllOrders(ctx context.Context, instrumentName string) err
 		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
 	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	params["instrument_name"] = instrumentName
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodCancelAllOrders,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to cancel signature: %w", err)
-	}
+	var cancelAllOrdersResponse CancelAllOrdersResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodCancelAllOrders,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCancelAllOrders,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to cancel signature: %w", err)
+		}
 
-	var cancelAllOrdersResponse CancelAllOrdersResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCancelAllOrders, &cancelAllOrdersResponse)
-	if err != nil {
-		return fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodCancelAllOrders,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		cancelAllOrdersResponse = CancelAllOrdersResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCancelAllOrders, &cancelAllOrdersResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, cancelAllOrdersResponse.Code); err != nil {
-		return fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, cancelAllOrdersResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return nil
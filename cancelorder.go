@@ -32,44 +32,53 @@ func (c *Client) CancelOrder(ctx context.Context, instrumentName string, orderID
 		return errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
 	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	params["instrument_name"] = instrumentName
 	params["order_id"] = orderID
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodCancelOrder,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to cancel signature: %w", err)
-	}
+	var cancelOrderResponse CancelOrderResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodCancelOrder,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCancelOrder,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to cancel signature: %w", err)
+		}
 
-	var cancelOrderResponse CancelOrderResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCancelOrder, &cancelOrderResponse)
-	if err != nil {
-		return fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodCancelOrder,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		cancelOrderResponse = CancelOrderResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCancelOrder, &cancelOrderResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, cancelOrderResponse.Code); err != nil {
-		return fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, cancelOrderResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,106 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const methodCancelOrderList = "private/cancel-order-list"
+
+// CancelOrderListResponse is the base response returned from the private/cancel-order-list API.
+type CancelOrderListResponse struct {
+	// api.BaseResponse is the common response fields.
+	api.BaseResponse
+	// Result is the response attributes of the endpoint.
+	Result OrderListResult `json:"result"`
+}
+
+// CancelOrderList cancels a list of up to 10 existing orders of a particular instrument on the Exchange
+// in a single request.
+//
+// This call is asynchronous, so the response is simply a confirmation of each cancellation.
+// Orders are cancelled individually: a result is returned for each order in orderIDs (in the same order),
+// with Err set to the errors.ResponseError of each order which could not be cancelled.
+// errors.ErrIncompleteOrderList is returned if the response does not contain a result for each order.
+//
+// Method: private/cancel-order-list
+func (c *Client) CancelOrderList(ctx context.Context, instrumentName string, orderIDs []string) ([]OrderListItemResult, error) {
+	if instrumentName == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
+	}
+	if len(orderIDs) == 0 {
+		return nil, errors.InvalidParameterError{Parameter: "orderIDs", Reason: "cannot be empty"}
+	}
+	if len(orderIDs) > maxOrderListSize {
+		return nil, errors.InvalidParameterError{Parameter: "orderIDs", Reason: "cannot contain more than 10 orders"}
+	}
+
+	orderList := make([]map[string]interface{}, 0, len(orderIDs))
+	for i, orderID := range orderIDs {
+		if orderID == "" {
+			return nil, errors.InvalidParameterError{Parameter: fmt.Sprintf("orderIDs[%d]", i), Reason: "cannot be empty"}
+		}
+		orderList = append(orderList, map[string]interface{}{
+			"instrument_name": instrumentName,
+			"order_id":        orderID,
+		})
+	}
+
+	params := make(map[string]interface{})
+
+	params["contingency_type"] = ContingencyTypeList
+	params["order_list"] = orderList
+
+	var (
+		cancelOrderListResponse CancelOrderListResponse
+		statusCode              int
+	)
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCancelOrderList,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodCancelOrderList,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		cancelOrderListResponse = CancelOrderListResponse{}
+		statusCode, err = c.requester.Post(ctx, body, methodCancelOrderList, &cancelOrderListResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, cancelOrderListResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orderListResults(statusCode, len(orderIDs), cancelOrderListResponse.Result.ResultList)
+}
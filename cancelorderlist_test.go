@@ -0,0 +1,282 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_CancelOrderList_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		instrumentName = "BTC_USDT"
+		orderID        = "1"
+	)
+	testErr := errors.New("some error")
+
+	type args struct {
+		instrumentName string
+		orderIDs       []string
+	}
+	validArgs := args{
+		instrumentName: instrumentName,
+		orderIDs:       []string{orderID},
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when instrument name is empty",
+			args: args{
+				orderIDs: []string{orderID},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "instrumentName",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when order ids is empty",
+			args: args{
+				instrumentName: instrumentName,
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "orderIDs",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when order ids contains more than 10 orders",
+			args: args{
+				instrumentName: instrumentName,
+				orderIDs:       make([]string, 11),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "orderIDs",
+				Reason:    "cannot contain more than 10 orders",
+			},
+		},
+		{
+			name: "returns error when an order id is empty",
+			args: args{
+				instrumentName: instrumentName,
+				orderIDs:       []string{orderID, ""},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "orderIDs[1]",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCancelOrderList,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"contingency_type": cdcexchange.ContingencyTypeList,
+						"order_list": []map[string]interface{}{
+							{"instrument_name": instrumentName, "order_id": orderID},
+						},
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.CancelOrderList(ctx, tt.instrumentName, tt.orderIDs)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_CancelOrderList_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrumentName = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodCancelOrderList)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodCancelOrderList, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, string(cdcexchange.ContingencyTypeList), body.Params["contingency_type"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"instrument_name": instrumentName, "order_id": "1"},
+			map[string]interface{}{"instrument_name": instrumentName, "order_id": "2"},
+		}, body.Params["order_list"])
+
+		res := `{
+  "id": 1234,
+  "method": "private/cancel-order-list",
+  "code": 0,
+  "result": {
+    "result_list": [
+      {
+        "index": 0,
+        "code": 0
+      },
+      {
+        "index": 1,
+        "code": 30005,
+        "message": "ORDER_TYPE_NOT_SUPPORTED"
+      }
+    ]
+  }
+}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodCancelOrderList,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"contingency_type": cdcexchange.ContingencyTypeList,
+			"order_list": []map[string]interface{}{
+				{"instrument_name": instrumentName, "order_id": "1"},
+				{"instrument_name": instrumentName, "order_id": "2"},
+			},
+		},
+	}).Return(signature, nil)
+
+	res, err := client.CancelOrderList(ctx, instrumentName, []string{"1", "2"})
+	require.NoError(t, err)
+
+	require.Len(t, res, 2)
+	assert.NoError(t, res[0].Err)
+	assert.Equal(t, "ORDER_TYPE_NOT_SUPPORTED", res[1].Message)
+	assert.True(t, errors.Is(res[1].Err, cdcerrors.ErrOrderTypeNotSupported))
+}
@@ -3,6 +3,7 @@ package cdcexchange
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 
@@ -28,6 +29,7 @@ type (
 		UpdateConfig(apiKey string, secretKey string, opts ...ClientOption) error
 		CommonAPI
 		SpotTradingAPI
+		WalletAPI
 		MarginTradingAPI
 		DerivativesTransferAPI
 		SubAccountAPI
@@ -50,6 +52,13 @@ type (
 		//
 		// Method: public/get-ticker
 		GetTickers(ctx context.Context, instrument string) ([]Ticker, error)
+		// Instruments returns the Client's instrument registry, which caches the instruments from GetInstruments.
+		Instruments() *InstrumentRegistry
+		// ServerTimeOffset returns the estimated offset of the Exchange's clock from the Client's clock,
+		// which is added to request nonces when WithServerTimeSync is set.
+		ServerTimeOffset() time.Duration
+		// SyncServerTime re-estimates the offset of the Exchange's clock from a public/get-book snapshot.
+		SyncServerTime(ctx context.Context) error
 	}
 
 	// SpotTradingAPI is a Crypto.com Exchange Client for Spot Trading API.
@@ -68,6 +77,19 @@ type (
 		//
 		// Method: private/create-order
 		CreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error)
+		// CreateOrderResolved creates a new BUY or SELL order on the Exchange, resolving whether the order was created
+		// if the request fails after it may have been sent (e.g. ctx is done or a 5xx response).
+		//
+		// A ClientOID is generated if req.ClientOID is empty, and is used to look up the order after an ambiguous failure.
+		//
+		// Methods: private/create-order, private/get-open-orders, private/get-order-history
+		CreateOrderResolved(ctx context.Context, req CreateOrderRequest) (*ResolvedCreateOrderResult, error)
+		// CreateOrderList creates a list of up to 10 BUY or SELL orders on the Exchange in a single request.
+		//
+		// A result is returned for each order (in the same order as reqs), with the errors.ResponseError of each rejected order.
+		//
+		// Method: private/create-order-list
+		CreateOrderList(ctx context.Context, reqs []CreateOrderRequest) ([]OrderListItemResult, error)
 		// CancelOrder cancels an existing order on the Exchange.
 		//
 		// This call is asynchronous, so the response is simply a confirmation of the request.
@@ -76,6 +98,13 @@ type (
 		//
 		// Method: private/cancel-order
 		CancelOrder(ctx context.Context, instrumentName string, orderID string) error
+		// CancelOrderList cancels a list of up to 10 existing orders of a particular instrument in a single request.
+		//
+		// A result is returned for each order (in the same order as orderIDs), with the errors.ResponseError of each order
+		// which could not be cancelled.
+		//
+		// Method: private/cancel-order-list
+		CancelOrderList(ctx context.Context, instrumentName string, orderIDs []string) ([]OrderListItemResult, error)
 		// CancelAllOrders cancels  all orders for a particular instrument/pair.
 		//
 		// This call is asynchronous, so the response is simply a confirmation of the request.
@@ -93,6 +122,18 @@ type (
 		//
 		// Method: private/get-order-history
 		GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest) ([]Order, error)
+		// IterateOrderHistory returns an iterator over the order history, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/get-order-history
+		IterateOrderHistory(req GetOrderHistoryRequest) *OrderHistoryIterator
+		// IterateOrderHistoryInRange returns an iterator over the order history between req.Start and req.End (Default: now),
+		// splitting the range into the 24 hour windows allowed by the Exchange.
+		//
+		// Results are returned in creation time order, without duplicates.
+		//
+		// Method: private/get-order-history
+		IterateOrderHistoryInRange(req GetOrderHistoryRequest) *OrderHistoryIterator
 		// GetOpenOrders gets all open orders for a particular instrument.
 		//
 		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
@@ -101,10 +142,26 @@ type (
 		//
 		// Method: private/get-open-orders
 		GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*GetOpenOrdersResult, error)
+		// IterateOpenOrders returns an iterator over the open orders, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/get-open-orders
+		IterateOpenOrders(req GetOpenOrdersRequest) *OpenOrderIterator
 		// GetOrderDetail gets details of an order for a particular order ID.
 		//
 		// Method: private/get-order-detail
 		GetOrderDetail(ctx context.Context, orderID string) (*GetOrderDetailResult, error)
+		// WaitForOrder polls the order details with backoff until the order matches predicate or reaches a terminal status.
+		//
+		// predicate can be left nil to wait for a terminal status (e.g. OrderFilled or OrderCancelled can be used).
+		//
+		// Method: private/get-order-detail
+		WaitForOrder(ctx context.Context, orderID string, predicate OrderPredicate) (*GetOrderDetailResult, error)
+		// NewOrderManager creates an OrderManager, which tracks orders & their fills in memory and reconciles them with
+		// the open orders every cfg.ReconcileInterval until ctx is done.
+		//
+		// Methods: private/create-order, private/get-open-orders, private/get-order-detail
+		NewOrderManager(ctx context.Context, cfg OrderManagerConfig) (*OrderManager, error)
 		// GetTrades gets all executed trades for a particular instrument.
 		//
 		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
@@ -114,22 +171,243 @@ type (
 		//
 		// Method: private/get-trades
 		GetTrades(ctx context.Context, req GetTradesRequest) ([]Trade, error)
+		// IterateTrades returns an iterator over the executed trades, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/get-trades
+		IterateTrades(req GetTradesRequest) *TradeIterator
+		// IterateTradesInRange returns an iterator over the executed trades between req.Start and req.End (Default: now),
+		// splitting the range into the 24 hour windows allowed by the Exchange.
+		//
+		// Results are returned in creation time order, without duplicates.
+		//
+		// Method: private/get-trades
+		IterateTradesInRange(req GetTradesRequest) *TradeIterator
+	}
+
+	// WalletAPI is a Crypto.com Exchange Client for deposits, withdrawals & balance history.
+	WalletAPI interface {
+		// CreateWithdrawal creates a withdrawal request to a whitelisted address.
+		//
+		// Withdrawal setting must be enabled for the API key.
+		//
+		// req.ClientWid should be set for the request to be safely retried.
+		//
+		// Method: private/create-withdrawal
+		CreateWithdrawal(ctx context.Context, req CreateWithdrawalRequest) (*CreateWithdrawalResult, error)
+		// GetWithdrawalHistory gets the withdrawal history for a particular currency.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, enumerate each page (starting with 0) until an empty withdrawal_list array appears in the response.
+		//
+		// req.Currency can be left blank to get withdrawals for all currencies.
+		//
+		// Method: private/get-withdrawal-history
+		GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHistoryRequest) ([]Withdrawal, error)
+		// IterateWithdrawalHistory returns an iterator over the withdrawal history, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/get-withdrawal-history
+		IterateWithdrawalHistory(req GetWithdrawalHistoryRequest) *WithdrawalIterator
+		// GetDepositHistory gets the deposit history for a particular currency.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, enumerate each page (starting with 0) until an empty deposit_list array appears in the response.
+		//
+		// req.Currency can be left blank to get deposits for all currencies.
+		//
+		// Method: private/get-deposit-history
+		GetDepositHistory(ctx context.Context, req GetDepositHistoryRequest) ([]Deposit, error)
+		// IterateDepositHistory returns an iterator over the deposit history, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/get-deposit-history
+		IterateDepositHistory(req GetDepositHistoryRequest) *DepositIterator
+		// GetDepositAddress gets the deposit addresses of a particular currency (one per network).
+		//
+		// Method: private/get-deposit-address
+		GetDepositAddress(ctx context.Context, req GetDepositAddressRequest) ([]DepositAddress, error)
+		// UserBalanceHistory gets the history of the total balance of the account.
+		//
+		// Method: private/user-balance-history
+		UserBalanceHistory(ctx context.Context, req UserBalanceHistoryRequest) (*UserBalanceHistoryResult, error)
+		// WaitForWithdrawal polls the withdrawal history with backoff until the withdrawal reaches a terminal status.
+		//
+		// The final withdrawal is returned once it is COMPLETED, otherwise WithdrawalFailedError is returned.
+		//
+		// CreateWithdrawalResult.WaitForWithdrawalRequest can be used to wait for a withdrawal which has just been created.
+		WaitForWithdrawal(ctx context.Context, req WaitForWithdrawalRequest) (*Withdrawal, error)
 	}
 
 	// MarginTradingAPI is a Crypto.com Exchange Client for Margin Trading API.
 	MarginTradingAPI interface {
+		// GetMarginAccountSummary returns the margin account balance of a user for a particular token.
+		//
+		// currency can be left blank to retrieve balances for ALL tokens.
+		//
+		// Method: private/margin/get-account-summary
+		GetMarginAccountSummary(ctx context.Context, currency string) (*MarginAccountSummaryResult, error)
+		// MarginTransfer transfers funds between the spot & margin accounts.
+		//
+		// Method: private/margin/transfer
+		MarginTransfer(ctx context.Context, req MarginTransferRequest) error
+		// MarginBorrow borrows funds against the balance of the margin account.
+		//
+		// Method: private/margin/borrow
+		MarginBorrow(ctx context.Context, req MarginBorrowRequest) error
+		// MarginRepay repays margin loans of a particular currency, along with their accrued interest.
+		//
+		// Method: private/margin/repay
+		MarginRepay(ctx context.Context, req MarginRepayRequest) error
+		// GetMarginBorrowHistory gets the margin loan history for a particular currency.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-borrow-history
+		GetMarginBorrowHistory(ctx context.Context, req GetMarginBorrowHistoryRequest) ([]MarginLoan, error)
+		// GetMarginRepayHistory gets the margin loan repayment history for a particular currency.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-repay-history
+		GetMarginRepayHistory(ctx context.Context, req GetMarginRepayHistoryRequest) ([]MarginRepayment, error)
+		// GetMarginInterestHistory gets the interest charged on margin loans of a particular currency.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// Method: private/margin/get-interest-history
+		GetMarginInterestHistory(ctx context.Context, req GetMarginInterestHistoryRequest) ([]MarginInterest, error)
+		// CreateMarginOrder creates a new BUY or SELL order on the Exchange, using the margin account.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// Method: private/margin/create-order
+		CreateMarginOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error)
+		// CancelMarginOrder cancels an existing margin order on the Exchange.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// Method: private/margin/cancel-order
+		CancelMarginOrder(ctx context.Context, instrumentName string, orderID string) error
+		// GetMarginOrderHistory gets the margin order history for a particular instrument.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		//
+		// req.InstrumentName can be left blank to get orders for all instruments.
+		//
+		// Method: private/margin/get-order-history
+		GetMarginOrderHistory(ctx context.Context, req GetOrderHistoryRequest) ([]Order, error)
 	}
 
 	// DerivativesTransferAPI is a Crypto.com Exchange Client for Derivatives Transfer API.
 	DerivativesTransferAPI interface {
+		// DerivativesTransfer transfers funds between the spot wallet & the derivatives account.
+		//
+		// Method: private/deriv/transfer
+		DerivativesTransfer(ctx context.Context, req DerivativesTransferRequest) error
+		// GetDerivativesTransferHistory gets the history of transfers to/from the derivatives account.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, enumerate each page (starting with 0) until an empty transfer_list array appears in the response.
+		//
+		// req.Direction & req.Currency can be left blank to get transfers in both directions, for all currencies.
+		//
+		// Method: private/deriv/get-transfer-history
+		GetDerivativesTransferHistory(ctx context.Context, req GetDerivativesTransferHistoryRequest) ([]DerivativesTransferRecord, error)
+		// IterateDerivativesTransferHistory returns an iterator over the derivatives transfer history, which fetches each page in turn
+		// (starting with req.Page) until an empty page is returned.
+		//
+		// Method: private/deriv/get-transfer-history
+		IterateDerivativesTransferHistory(req GetDerivativesTransferHistoryRequest) *DerivativesTransferIterator
 	}
 
 	// SubAccountAPI is a Crypto.com Exchange Client for Sub-account API.
 	SubAccountAPI interface {
+		// GetSubAccounts gets the sub-accounts of the master account.
+		//
+		// Method: private/subaccount/get-sub-accounts
+		GetSubAccounts(ctx context.Context) ([]SubAccount, error)
+		// GetSubAccountBalances gets the balances of all sub-accounts of the master account.
+		//
+		// Method: private/get-subaccount-balances
+		GetSubAccountBalances(ctx context.Context) ([]SubAccountBalance, error)
+		// SubAccountTransfer transfers funds between the master account & a sub-account, or between sub-accounts.
+		//
+		// Method: private/subaccount/transfer
+		SubAccountTransfer(ctx context.Context, req SubAccountTransferRequest) error
+		// GetSubAccountTransferHistory gets the history of transfers between the master account & sub-accounts.
+		//
+		// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+		// If paging is used, enumerate each page (starting with 0) until an empty transfer_list array appears in the response.
+		//
+		// req.SubAccountUUID, req.Direction & req.Currency can be left blank to get transfers of all sub-accounts,
+		// in both directions, for all currencies.
+		//
+		// Method: private/subaccount/get-transfer-history
+		GetSubAccountTransferHistory(ctx context.Context, req GetSubAccountTransferHistoryRequest) ([]SubAccountTransferRecord, error)
+		// SubAccount returns a SubAccountClient which acts on behalf of the sub-account with the given UUID.
+		SubAccount(uuid string) *SubAccountClient
 	}
 
 	// Websocket is a Crypto.com Exchange Client websocket methods & channels.
+	//
+	// Channels returned from subscriptions are closed once ctx is done or the websocket connection is closed.
 	Websocket interface {
+		// SubscribeBook subscribes to order book updates for a particular instrument and depth.
+		//
+		// depth can be left as 0 to use the Exchange default.
+		//
+		// Channel: book.{instrument_name}.{depth}
+		SubscribeBook(ctx context.Context, instrument string, depth int) (<-chan BookData, error)
+		// SubscribeTickers subscribes to ticker updates for a particular instrument.
+		//
+		// Channel: ticker.{instrument_name}
+		SubscribeTickers(ctx context.Context, instrument string) (<-chan Ticker, error)
+		// SubscribeTrades subscribes to public trades for a particular instrument.
+		//
+		// Channel: trade.{instrument_name}
+		SubscribeTrades(ctx context.Context, instrument string) (<-chan Trade, error)
+		// SubscribeCandlesticks subscribes to candlestick updates for a particular instrument and interval.
+		//
+		// Channel: candlestick.{interval}.{instrument_name}
+		SubscribeCandlesticks(ctx context.Context, interval CandlestickInterval, instrument string) (<-chan Candlestick, error)
+		// SubscribeUserOrders subscribes to updates of the user's orders for a particular instrument.
+		//
+		// Channel: user.order.{instrument_name}
+		SubscribeUserOrders(ctx context.Context, instrument string) (<-chan Order, error)
+		// SubscribeUserTrades subscribes to the user's executed trades for a particular instrument.
+		//
+		// Channel: user.trade.{instrument_name}
+		SubscribeUserTrades(ctx context.Context, instrument string) (<-chan Trade, error)
+		// SubscribeUserBalance subscribes to updates of the user's balances.
+		//
+		// Channel: user.balance
+		SubscribeUserBalance(ctx context.Context) (<-chan Account, error)
+		// WebsocketCreateOrder creates a new BUY or SELL order on the Exchange over the user websocket.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// Method: private/create-order
+		WebsocketCreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error)
+		// WebsocketCancelOrder cancels an existing order on the Exchange over the user websocket.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// Method: private/cancel-order
+		WebsocketCancelOrder(ctx context.Context, instrumentName string, orderID string) error
+		// WebsocketCancelAllOrders cancels all orders for a particular instrument/pair over the user websocket.
+		//
+		// This call is asynchronous, so the response is simply a confirmation of the request.
+		//
+		// Method: private/cancel-all-orders
+		WebsocketCancelAllOrders(ctx context.Context, instrumentName string) error
+		// SubscribeWebsocketEvents subscribes to websocket lifecycle events (disconnects & resyncs).
+		//
+		// A RESYNCED event is published once a lost connection has been re-established
+		// and its subscriptions replayed (see WithWebsocketReconnect).
+		SubscribeWebsocketEvents(ctx context.Context) <-chan StreamEvent
+		// Close closes any open websocket connections.
+		Close() error
 	}
 
 	// Environment represents the environment against which calls are made.
@@ -146,20 +424,37 @@ type (
 		idGenerator        id.IDGenerator
 		signatureGenerator auth.SignatureGenerator
 		requester          api.Requester
+		market             *stream
+		user               *stream
+		reconnectPolicy    *ReconnectPolicy
+		retryPolicy        *RetryPolicy
+		serverTime         serverTime
+		events             eventBus
+		validateOrders     bool
+		instruments        *InstrumentRegistry
+		withdrawalGuard    *withdrawalGuard
+		orderPollPolicy    PollPolicy
+		resolvePolicy      ResolvePolicy
 	}
 )
 
 // New will construct a new instance of Client.
 func New(apiKey string, secretKey string, opts ...ClientOption) (*Client, error) {
+	clock := clockwork.NewRealClock()
+
 	c := &Client{
 		idGenerator:        &id.Generator{},
 		signatureGenerator: &auth.Generator{},
-		clock:              clockwork.NewRealClock(),
+		clock:              clock,
 		requester: api.Requester{
-			Client:  http.DefaultClient,
-			BaseURL: productionBaseURL,
+			Client:      http.DefaultClient,
+			BaseURL:     productionBaseURL,
+			RateLimiter: api.NewRateLimiter(clock),
 		},
 	}
+	c.market = newStream(c, StreamMarket, productionMarketWebsocketURL, nil)
+	c.user = newStream(c, StreamUser, productionUserWebsocketURL, c.authenticate)
+	c.instruments = newInstrumentRegistry(c)
 
 	if err := c.UpdateConfig(apiKey, secretKey, opts...); err != nil {
 		return nil, err
@@ -195,6 +490,8 @@ func (c *Client) UpdateConfig(apiKey string, secretKey string, opts ...ClientOpt
 func WithProductionEnvironment() ClientOption {
 	return func(c *Client) error {
 		c.requester.BaseURL = productionBaseURL
+		c.market.url = productionMarketWebsocketURL
+		c.user.url = productionUserWebsocketURL
 		return nil
 	}
 }
@@ -203,6 +500,8 @@ func WithProductionEnvironment() ClientOption {
 func WithUATEnvironment() ClientOption {
 	return func(c *Client) error {
 		c.requester.BaseURL = uatSandboxBaseURL
+		c.market.url = uatSandboxMarketWebsocketURL
+		c.user.url = uatSandboxUserWebsocketURL
 		return nil
 	}
 }
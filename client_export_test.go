@@ -14,6 +14,11 @@ const (
 	UATSandboxBaseURL = uatSandboxBaseURL
 	ProductionBaseURL = productionBaseURL
 
+	UATSandboxMarketWebsocketURL = uatSandboxMarketWebsocketURL
+	ProductionMarketWebsocketURL = productionMarketWebsocketURL
+	UATSandboxUserWebsocketURL   = uatSandboxUserWebsocketURL
+	ProductionUserWebsocketURL   = productionUserWebsocketURL
+
 	// Common API
 	MethodGetInstruments = methodGetInstruments
 	MethodGetBook        = methodGetBook
@@ -23,11 +28,45 @@ const (
 	MethodGetAccountSummary = methodGetAccountSummary
 	MethodCreateOrder       = methodCreateOrder
 	MethodCancelOrder       = methodCancelOrder
+	MethodCreateOrderList   = methodCreateOrderList
+	MethodCancelOrderList   = methodCancelOrderList
 	MethodCancelAllOrders   = methodCancelAllOrders
 	MethodGetOrderHistory   = methodGetOrderHistory
 	MethodGetOpenOrders     = methodGetOpenOrders
 	MethodGetOrderDetail    = methodGetOrderDetail
 	MethodGetTrades         = methodGetTrades
+
+	// Wallet API
+	MethodCreateWithdrawal     = methodCreateWithdrawal
+	MethodGetWithdrawalHistory = methodGetWithdrawalHistory
+	MethodGetDepositHistory    = methodGetDepositHistory
+	MethodGetDepositAddress    = methodGetDepositAddress
+	MethodUserBalanceHistory   = methodUserBalanceHistory
+
+	// Margin Trading API
+	MethodGetMarginAccountSummary  = methodGetMarginAccountSummary
+	MethodMarginTransfer           = methodMarginTransfer
+	MethodMarginBorrow             = methodMarginBorrow
+	MethodMarginRepay              = methodMarginRepay
+	MethodGetMarginBorrowHistory   = methodGetMarginBorrowHistory
+	MethodGetMarginRepayHistory    = methodGetMarginRepayHistory
+	MethodGetMarginInterestHistory = methodGetMarginInterestHistory
+	MethodCreateMarginOrder        = methodCreateMarginOrder
+	MethodCancelMarginOrder        = methodCancelMarginOrder
+	MethodGetMarginOrderHistory    = methodGetMarginOrderHistory
+
+	// Derivatives Transfer API
+	MethodDerivativesTransfer           = methodDerivativesTransfer
+	MethodGetDerivativesTransferHistory = methodGetDerivativesTransferHistory
+
+	// Sub-account API
+	MethodGetSubAccounts               = methodGetSubAccounts
+	MethodGetSubAccountBalances        = methodGetSubAccountBalances
+	MethodSubAccountTransfer           = methodSubAccountTransfer
+	MethodGetSubAccountTransferHistory = methodGetSubAccountTransferHistory
+
+	// Websocket
+	MethodAuth = methodAuth
 )
 
 func (c *Client) BaseURL() string {
@@ -46,6 +85,14 @@ func (c *Client) HTTPClient() *http.Client {
 	return c.requester.Client
 }
 
+func (c *Client) MarketWebsocketURL() string {
+	return c.market.url
+}
+
+func (c *Client) UserWebsocketURL() string {
+	return c.user.url
+}
+
 func WithIDGenerator(idGenerator id.IDGenerator) ClientOption {
 	return func(c *Client) error {
 		if idGenerator == nil {
@@ -75,6 +122,7 @@ func WithClock(clock clockwork.Clock) ClientOption {
 		}
 
 		c.clock = clock
+		c.requester.RateLimiter.Clock = clock
 		return nil
 	}
 }
@@ -89,3 +137,25 @@ func WithBaseURL(url string) ClientOption {
 		return nil
 	}
 }
+
+func WithMarketWebsocketURL(url string) ClientOption {
+	return func(c *Client) error {
+		if url == "" {
+			return errors.InvalidParameterError{Parameter: "url", Reason: "cannot be empty"}
+		}
+
+		c.market.url = url
+		return nil
+	}
+}
+
+func WithUserWebsocketURL(url string) ClientOption {
+	return func(c *Client) error {
+		if url == "" {
+			return errors.InvalidParameterError{Parameter: "url", Reason: "cannot be empty"}
+		}
+
+		c.user.url = url
+		return nil
+	}
+}
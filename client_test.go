@@ -3,9 +3,12 @@ package cdcexchange_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 
 	cdcexchange "github.com/sngyai/go-cryptocom"
 	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
 )
 
 type roundTripper struct {
@@ -44,6 +48,51 @@ func (rt roundTripper) RoundTrip(*http.Request) (*http.Response, error) {
 	}, rt.err
 }
 
+// apiServer starts a REST API test server, returning it along with a func which returns every request received.
+//
+// respond is called with each request, its decoded body and the number of requests received before it,
+// returning the response body to write (a status code can be written to w first).
+func apiServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string) (*httptest.Server, func() []api.Request) {
+	var (
+		mu       sync.Mutex
+		requests []api.Request
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, r.URL.Path, body.Method)
+
+		mu.Lock()
+		n := len(requests)
+		requests = append(requests, body)
+		mu.Unlock()
+
+		_, err := w.Write([]byte(respond(w, r, body, n)))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	return s, func() []api.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]api.Request(nil), requests...)
+	}
+}
+
+// okResponse returns a successful response body with result (JSON).
+func okResponse(result string) string {
+	return fmt.Sprintf(`{"id":0,"method":"","code":0,"result":%s}`, result)
+}
+
+// nthOrLast returns the nth item, or the last item once there are no more.
+func nthOrLast(items []string, n int) string {
+	if n < len(items) {
+		return items[n]
+	}
+	return items[len(items)-1]
+}
+
 func TestNew_Error(t *testing.T) {
 	type args struct {
 		apiKey    string
@@ -91,7 +140,9 @@ func TestNew_Success(t *testing.T) {
 	tests := []struct {
 		name string
 		args
-		expectedBaseURL string
+		expectedBaseURL            string
+		expectedMarketWebsocketURL string
+		expectedUserWebsocketURL   string
 	}{
 		{
 			name: "successfully creates UAT Client",
@@ -100,7 +151,9 @@ func TestNew_Success(t *testing.T) {
 				secretKey: "secret key",
 				opts:      []cdcexchange.ClientOption{cdcexchange.WithUATEnvironment()},
 			},
-			expectedBaseURL: cdcexchange.UATSandboxBaseURL,
+			expectedBaseURL:            cdcexchange.UATSandboxBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.UATSandboxMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.UATSandboxUserWebsocketURL,
 		},
 		{
 			name: "successfully creates production Client",
@@ -108,7 +161,9 @@ func TestNew_Success(t *testing.T) {
 				apiKey:    "api key",
 				secretKey: "secret key",
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 		{
 			name: "successfully creates Client with custom http Client",
@@ -118,7 +173,9 @@ func TestNew_Success(t *testing.T) {
 				httpClient: &http.Client{Timeout: time.Minute},
 				opts:       []cdcexchange.ClientOption{cdcexchange.WithHTTPClient(&http.Client{Timeout: time.Minute})},
 			},
-			expectedBaseURL: cdcexchange.ProductionBaseURL,
+			expectedBaseURL:            cdcexchange.ProductionBaseURL,
+			expectedMarketWebsocketURL: cdcexchange.ProductionMarketWebsocketURL,
+			expectedUserWebsocketURL:   cdcexchange.ProductionUserWebsocketURL,
 		},
 	}
 	for _, tt := range tests {
@@ -130,6 +187,8 @@ func TestNew_Success(t *testing.T) {
 			assert.Equal(t, tt.apiKey, client.APIKey())
 			assert.Equal(t, tt.secretKey, client.SecretKey())
 			assert.Equal(t, tt.expectedBaseURL, client.BaseURL())
+			assert.Equal(t, tt.expectedMarketWebsocketURL, client.MarketWebsocketURL())
+			assert.Equal(t, tt.expectedUserWebsocketURL, client.UserWebsocketURL())
 
 			if tt.httpClient == nil {
 				assert.Equal(t, http.DefaultClient, client.HTTPClient())
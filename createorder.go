@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
 )
@@ -62,13 +64,13 @@ type (
 		Type OrderType `json:"type"`
 		// Price determines the price of which the trade should be executed.
 		// For LIMIT and STOP_LIMIT orders only.
-		Price float64 `json:"price"`
+		Price decimal.Decimal `json:"price"`
 		// Quantity is the quantity to be sold
 		// For LIMIT, MARKET, STOP_LOSS, TAKE_PROFIT orders only.
-		Quantity float64 `json:"quantity"`
+		Quantity decimal.Decimal `json:"quantity"`
 		// Notional is the amount to spend.
 		// For MARKET (BUY), STOP_LOSS (BUY), TAKE_PROFIT (BUY) orders only.
-		Notional float64 `json:"notional"`
+		Notional decimal.Decimal `json:"notional"`
 		// ClientOID is the optional Client order ID.
 		ClientOID string `json:"client_oid"`
 		// TimeInForce represents how long the order should be active before being cancelled.
@@ -83,7 +85,7 @@ type (
 		ExecInst ExecInst `json:"exec_inst"`
 		// TriggerPrice is the price at which the order is triggered.
 		// Used with STOP_LOSS, STOP_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
-		TriggerPrice float64 `json:"trigger_price"`
+		TriggerPrice decimal.Decimal `json:"trigger_price"`
 	}
 
 	// CreateOrderResponse is the base response returned from the private/create-order API.
@@ -109,13 +111,68 @@ type (
 //
 // The user.order subscription can be used to check when the order is successfully created.
 //
+// If the Client was created WithOrderValidation, req is validated against its instrument before being sent.
+//
 // Method: private/create-order
 func (c *Client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	if err := c.validateOrder(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to validate order: %w", err)
+	}
+
+	params := createOrderParams(req)
+
+	var createOrderResponse CreateOrderResponse
+	err := c.retry(ctx, req.ClientOID != "", func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCreateOrder,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodCreateOrder,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		createOrderResponse = CreateOrderResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCreateOrder, &createOrderResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, createOrderResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &createOrderResponse.Result, nil
+}
+
+// createOrderParams builds the params for the private/create-order API, omitting any fields which are not set.
+//
+// Decimals are sent as strings so that the signature is generated from the exact value sent.
+func createOrderParams(req CreateOrderRequest) map[string]interface{} {
+	params := make(map[string]interface{})
 
 	if req.InstrumentName != "" {
 		params["instrument_name"] = req.InstrumentName
@@ -126,14 +183,14 @@ func (c *Client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 	if req.Type != "" {
 		params["type"] = req.Type
 	}
-	if req.Price != 0 {
-		params["price"] = req.Price
+	if !req.Price.IsZero() {
+		params["price"] = req.Price.String()
 	}
-	if req.Quantity != 0 {
-		params["quantity"] = req.Quantity
+	if !req.Quantity.IsZero() {
+		params["quantity"] = req.Quantity.String()
 	}
-	if req.Notional != 0 {
-		params["notional"] = req.Notional
+	if !req.Notional.IsZero() {
+		params["notional"] = req.Notional.String()
 	}
 	if req.ClientOID != "" {
 		params["client_oid"] = req.ClientOID
@@ -144,40 +201,9 @@ func (c *Client) CreateOrder(ctx context.Context, req CreateOrderRequest) (*Crea
 	if req.ExecInst != "" {
 		params["exec_inst"] = req.ExecInst
 	}
-	if req.TriggerPrice != 0 {
-		params["trigger_price"] = req.TriggerPrice
+	if !req.TriggerPrice.IsZero() {
+		params["trigger_price"] = req.TriggerPrice.String()
 	}
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodCreateOrder,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
-
-	body := api.Request{
-		ID:        id,
-		Method:    methodCreateOrder,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
-
-	var createOrderResponse CreateOrderResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCreateOrder, &createOrderResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
-
-	if err := c.requester.CheckErrorResponse(statusCode, createOrderResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
-	}
-
-	return &createOrderResponse.Result, nil
+	return params
 }
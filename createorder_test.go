@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -132,13 +133,13 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 		instrument   = "some instrument"
 		orderSide    = cdcexchange.OrderSideBuy
 		orderType    = cdcexchange.OrderTypeMarket
-		price        = 1.234
-		quantity     = 5.678
-		notional     = 9.012
+		price        = "1.234"
+		quantity     = "5.678"
+		notional     = "9.012"
 		clientOID    = "some Client oid"
 		timeInForce  = cdcexchange.TimeInForceGoodTilCancelled
 		execInst     = cdcexchange.ExecInstPostOnly
-		triggerPrice = 3.456
+		triggerPrice = "3.456"
 
 		orderID = "5678"
 	)
@@ -161,13 +162,13 @@ func TestClient_CreateOrder_Success(t *testing.T) {
 					InstrumentName: instrument,
 					Side:           orderSide,
 					Type:           orderType,
-					Price:          price,
-					Quantity:       quantity,
-					Notional:       notional,
+					Price:          decimal.RequireFromString(price),
+					Quantity:       decimal.RequireFromString(quantity),
+					Notional:       decimal.RequireFromString(notional),
 					ClientOID:      clientOID,
 					TimeInForce:    timeInForce,
 					ExecInst:       execInst,
-					TriggerPrice:   triggerPrice,
+					TriggerPrice:   decimal.RequireFromString(triggerPrice),
 				},
 			},
 			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,162 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodCreateOrderList = "private/create-order-list"
+
+	// maxOrderListSize is the maximum number of orders in a private/create-order-list or private/cancel-order-list request.
+	maxOrderListSize = 10
+
+	ContingencyTypeList ContingencyType = "LIST"
+)
+
+type (
+	// ContingencyType is the type of a list of orders (LIST).
+	ContingencyType string
+
+	// CreateOrderListResponse is the base response returned from the private/create-order-list API.
+	CreateOrderListResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result OrderListResult `json:"result"`
+	}
+
+	// OrderListResult is the result returned from the private/create-order-list & private/cancel-order-list APIs.
+	OrderListResult struct {
+		// ResultList is the result of each order in the list.
+		ResultList []OrderListItemResult `json:"result_list"`
+	}
+
+	// OrderListItemResult is the result of a single order in a private/create-order-list
+	// or private/cancel-order-list request.
+	OrderListItemResult struct {
+		// Index is the index of the order in the request (0-based).
+		Index int `json:"index"`
+		// Code is the response code of the order (0 if it was successful).
+		Code int64 `json:"code"`
+		// Message is the description of the error (if Code is not 0).
+		Message string `json:"message"`
+		// OrderID is the ID of the order.
+		OrderID string `json:"order_id"`
+		// ClientOID is the optional Client order ID (if provided in request).
+		ClientOID string `json:"client_oid"`
+		// Err is the errors.ResponseError of the order, nil if it was successful.
+		Err error `json:"-"`
+	}
+)
+
+// CreateOrderList creates a list of up to 10 BUY or SELL orders on the Exchange in a single request.
+//
+// This call is asynchronous, so the response is simply a confirmation of each order.
+// Orders are accepted or rejected individually: a result is returned for each order in reqs (in the same order),
+// with Err set to the errors.ResponseError of each rejected order.
+// errors.ErrIncompleteOrderList is returned if the response does not contain a result for each order.
+//
+// If the Client was created WithOrderValidation, each order is validated against its instrument before being sent.
+//
+// Method: private/create-order-list
+func (c *Client) CreateOrderList(ctx context.Context, reqs []CreateOrderRequest) ([]OrderListItemResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.InvalidParameterError{Parameter: "reqs", Reason: "cannot be empty"}
+	}
+	if len(reqs) > maxOrderListSize {
+		return nil, errors.InvalidParameterError{Parameter: "reqs", Reason: "cannot contain more than 10 orders"}
+	}
+
+	var (
+		orderList  = make([]map[string]interface{}, 0, len(reqs))
+		idempotent = true
+	)
+	for i, req := range reqs {
+		if err := c.validateOrder(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to validate order %d: %w", i, err)
+		}
+		orderList = append(orderList, createOrderParams(req))
+		idempotent = idempotent && req.ClientOID != ""
+	}
+
+	params := make(map[string]interface{})
+
+	params["contingency_type"] = ContingencyTypeList
+	params["order_list"] = orderList
+
+	var (
+		createOrderListResponse CreateOrderListResponse
+		statusCode              int
+	)
+	err := c.retry(ctx, idempotent, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCreateOrderList,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodCreateOrderList,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		createOrderListResponse = CreateOrderListResponse{}
+		statusCode, err = c.requester.Post(ctx, body, methodCreateOrderList, &createOrderListResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, createOrderListResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orderListResults(statusCode, len(reqs), createOrderListResponse.Result.ResultList)
+}
+
+// orderListResults sorts the results of a list of n orders by index, setting the errors.ResponseError of each failed order.
+// errors.ErrIncompleteOrderList is returned unless there is exactly one result for each order.
+func orderListResults(statusCode int, n int, results []OrderListItemResult) ([]OrderListItemResult, error) {
+	if len(results) != n {
+		return nil, fmt.Errorf("got %d results for %d orders: %w", len(results), n, errors.ErrIncompleteOrderList)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Index < results[j].Index
+	})
+
+	for i, res := range results {
+		if res.Index != i {
+			return nil, fmt.Errorf("missing the result of order %d: %w", i, errors.ErrIncompleteOrderList)
+		}
+		results[i].Err = errors.NewResponseError(statusCode, res.Code)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,318 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_CreateOrderList_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	order := cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          decimal.NewFromInt(100),
+		Quantity:       decimal.NewFromInt(1),
+	}
+
+	type args struct {
+		reqs []cdcexchange.CreateOrderRequest
+	}
+	validArgs := args{
+		reqs: []cdcexchange.CreateOrderRequest{order},
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when reqs is empty",
+			args: args{},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "reqs",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when reqs contains more than 10 orders",
+			args: args{
+				reqs: make([]cdcexchange.CreateOrderRequest, 11),
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "reqs",
+				Reason:    "cannot contain more than 10 orders",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+		{
+			name: "returns error given a result missing from the result list",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					response: cdcexchange.CreateOrderListResponse{},
+				},
+			},
+			expectedErr: cdcerrors.ErrIncompleteOrderList,
+		},
+		{
+			name: "returns error given a result for an order which is not in the list",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					response: cdcexchange.CreateOrderListResponse{
+						Result: cdcexchange.OrderListResult{
+							ResultList: []cdcexchange.OrderListItemResult{{Index: 1}},
+						},
+					},
+				},
+			},
+			expectedErr: cdcerrors.ErrIncompleteOrderList,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCreateOrderList,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"contingency_type": cdcexchange.ContingencyTypeList,
+						"order_list": []map[string]interface{}{
+							{
+								"instrument_name": order.InstrumentName,
+								"side":            order.Side,
+								"type":            order.Type,
+								"price":           order.Price.String(),
+								"quantity":        order.Quantity.String(),
+							},
+						},
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.CreateOrderList(ctx, tt.reqs)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_CreateOrderList_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	reqs := []cdcexchange.CreateOrderRequest{
+		{
+			InstrumentName: "BTC_USDT",
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeLimit,
+			Price:          decimal.RequireFromString("100.5"),
+			Quantity:       decimal.NewFromInt(1),
+			ClientOID:      "first",
+		},
+		{
+			InstrumentName: "BTC_USDT",
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeLimit,
+			Price:          decimal.NewFromInt(99),
+			Quantity:       decimal.NewFromInt(1000),
+			ClientOID:      "second",
+		},
+	}
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateOrderList)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodCreateOrderList, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, string(cdcexchange.ContingencyTypeList), body.Params["contingency_type"])
+
+		orderList, ok := body.Params["order_list"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, orderList, 2)
+		assert.Equal(t, map[string]interface{}{
+			"instrument_name": "BTC_USDT",
+			"side":            "BUY",
+			"type":            "LIMIT",
+			"price":           "100.5",
+			"quantity":        "1",
+			"client_oid":      "first",
+		}, orderList[0])
+
+		res := `{
+  "id": 1234,
+  "method": "private/create-order-list",
+  "code": 0,
+  "result": {
+    "result_list": [
+      {
+        "index": 1,
+        "code": 20002,
+        "message": "NEGATIVE_BALANCE",
+        "client_oid": "second"
+      },
+      {
+        "index": 0,
+        "code": 0,
+        "order_id": "2015106383706015873",
+        "client_oid": "first"
+      }
+    ]
+  }
+}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(gomock.Any()).Return(signature, nil)
+
+	res, err := client.CreateOrderList(ctx, reqs)
+	require.NoError(t, err)
+
+	require.Len(t, res, 2)
+
+	assert.Equal(t, 0, res[0].Index)
+	assert.Equal(t, "2015106383706015873", res[0].OrderID)
+	assert.Equal(t, "first", res[0].ClientOID)
+	assert.NoError(t, res[0].Err)
+
+	assert.Equal(t, 1, res[1].Index)
+	assert.Equal(t, "second", res[1].ClientOID)
+	assert.Equal(t, "NEGATIVE_BALANCE", res[1].Message)
+	assert.Equal(t, cdcerrors.ResponseError{
+		Code:           20002,
+		HTTPStatusCode: http.StatusOK,
+		Err:            cdcerrors.ErrNegativeBalance,
+	}, res[1].Err)
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
+	"github.com/sngyai/go-cryptocom/internal/time"
 )
 
 const (
@@ -13,24 +17,27 @@ const (
 )
 
 type (
+	// NetworkID identifies the network a currency is withdrawn on (e.g. ETH or BSC).
+	NetworkID string
+
 	// CreateWithdrawalRequest is the request params sent for the private/create-withdrawal API.
 	//
-	// The maximum duration between Start and EndTime is 24 hours.
-	//
-	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
-	//
-	// For users looking to pull longer historical withdrawal data, users can create a loop to make a request
-	// for each 24-period from the desired start to end time.
+	// The withdrawal address must be whitelisted for the account on the Exchange.
 	CreateWithdrawalRequest struct {
-		// Currency represents the currency symbol for the withdrawals (e.g. BTC or ETH).
-		// if Currency is omitted, all currencies will be returned.
-		Currency string  `json:"currency"`
-		Amount   float64 `json:"amount"`
-		Address  string  `json:"address"`
+		// Currency represents the currency symbol to withdraw (e.g. BTC or ETH).
+		Currency string `json:"currency"`
+		// Amount represents the amount to withdraw.
+		Amount decimal.Decimal `json:"amount"`
+		// Address represents the address to withdraw to.
+		Address string `json:"address"`
 
-		ClientWid  string `json:"client_wid"`
+		// ClientWid represents the optional client withdrawal ID, which makes the request safe to retry.
+		ClientWid string `json:"client_wid"`
+		// AddressTag represents the optional secondary address identifier for coins like XRP, XLM etc. (also known as memo or tag).
 		AddressTag string `json:"address_tag"`
-		NetworkId  string `json:"network_id"`
+		// NetworkId represents the optional network to withdraw on (e.g. ETH or BSC).
+		// if NetworkId is omitted, the default network of the currency will be used.
+		NetworkId NetworkID `json:"network_id"`
 	}
 
 	// CreateWithdrawalResponse is the base response returned from the private/create-withdrawal API.
@@ -43,45 +50,48 @@ type (
 
 	// CreateWithdrawalResult is the result returned from the private/create-withdrawal API.
 	CreateWithdrawalResult struct {
-		Id         int64   `json:"id"`
-		Amount     float64 `json:"amount"`
-		Fee        float64 `json:"fee"`
-		Symbol     string  `json:"symbol"`
-		Address    string  `json:"address"`
-		ClientWid  string  `json:"client_wid"`
-		CreateTime int64   `json:"create_time"`
-		NetworkId  string  `json:"network_id"`
+		Id         int64           `json:"id"`
+		Amount     decimal.Decimal `json:"amount"`
+		Fee        decimal.Decimal `json:"fee"`
+		Symbol     string          `json:"symbol"`
+		Address    string          `json:"address"`
+		ClientWid  string          `json:"client_wid"`
+		CreateTime time.Time       `json:"create_time"`
+		NetworkId  NetworkID       `json:"network_id"`
 	}
 )
 
-// CreateWithdrawal gets the withdrawal history for a particular instrument.
+// CreateWithdrawal creates a withdrawal request to a whitelisted address.
 //
-// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-// If paging is used, enumerate each page (starting with 0) until an empty withdrawal_list array appears in the response.
+// Withdrawal setting must be enabled for the API key.
 //
-// req.Timeframe can be left blank to get withdrawals for all instruments.
+// If WithWithdrawalGuard is set, the withdrawal must pass the guard's checks before it is sent.
 //
 // Method: private/create-withdrawal
 func (c *Client) CreateWithdrawal(ctx context.Context, req CreateWithdrawalRequest) (*CreateWithdrawalResult, error) {
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
-
-	if req.Currency != "" {
-		params["currency"] = req.Currency
+	if req.Currency == "" {
+		return nil, errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
 	}
-	if req.ClientWid != "" {
-		params["client_wid"] = req.ClientWid
+	if !req.Amount.IsPositive() {
+		return nil, errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
 	}
-	if req.Amount != 0 {
-		params["amount"] = req.Amount
+	if req.Address == "" {
+		return nil, errors.InvalidParameterError{Parameter: "req.Address", Reason: "cannot be empty"}
 	}
-	if req.Address != "" {
-		params["address"] = req.Address
+
+	release, err := c.guardWithdrawal(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
+	params := make(map[string]interface{})
+
+	params["currency"] = req.Currency
+	params["amount"] = req.Amount.String()
+	params["address"] = req.Address
+	if req.ClientWid != "" {
+		params["client_wid"] = req.ClientWid
+	}
 	if req.AddressTag != "" {
 		params["address_tag"] = req.AddressTag
 	}
@@ -89,36 +99,52 @@ func (c *Client) CreateWithdrawal(ctx context.Context, req CreateWithdrawalReque
 		params["network_id"] = req.NetworkId
 	}
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodCreateWithdrawal,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
+	var createWithdrawalResponse CreateWithdrawalResponse
+	err = c.retry(ctx, req.ClientWid != "", func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodCreateWithdrawal,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCreateWithdrawal,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
 
-	var CreateWithdrawalResponse CreateWithdrawalResponse
-	statusCode, err := c.requester.Post(ctx, body, methodCreateWithdrawal, &CreateWithdrawalResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodCreateWithdrawal,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		createWithdrawalResponse = CreateWithdrawalResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCreateWithdrawal, &createWithdrawalResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, createWithdrawalResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, CreateWithdrawalResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil
+	})
+	if err != nil {
+		if withdrawalRejected(err) {
+			release()
+		}
+		return nil, err
 	}
 
-	return &CreateWithdrawalResponse.Result, nil
+	return &createWithdrawalResponse.Result, nil
 }
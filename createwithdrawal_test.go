@@ -0,0 +1,389 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+func TestClient_CreateWithdrawal_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		currency  = "BTC"
+		address   = "some address"
+	)
+	testErr := errors.New("some error")
+	amount := decimal.RequireFromString("1.5")
+
+	type args struct {
+		req cdcexchange.CreateWithdrawalRequest
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		responseErr  error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Amount:  amount,
+					Address: address,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when amount is 0",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Address:  address,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "returns error when amount is negative",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   amount.Neg(),
+					Address:  address,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "returns error when address is empty",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   amount,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Address",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error given error generating signature",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   amount,
+					Address:  address,
+				},
+			},
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   amount,
+					Address:  address,
+				},
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   amount,
+					Address:  address,
+				},
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusBadRequest,
+					response: api.BaseResponse{
+						Code: "20002",
+					},
+				},
+			},
+			responseErr: nil,
+			expectedErr: cdcerrors.ResponseError{
+				Code:           20002,
+				HTTPStatusCode: http.StatusBadRequest,
+				Err:            cdcerrors.ErrNegativeBalance,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCreateWithdrawal,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": currency,
+						"amount":   "1.5",
+						"address":  address,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.CreateWithdrawal(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_CreateWithdrawal_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		currency   = "XRP"
+		address    = "some address"
+		clientWid  = "some client wid"
+		addressTag = "some address tag"
+		networkID  = cdcexchange.NetworkID("XRP")
+	)
+	now := time.Now().Round(time.Second)
+
+	type args struct {
+		req cdcexchange.CreateWithdrawalRequest
+	}
+	tests := []struct {
+		name        string
+		handlerFunc func(w http.ResponseWriter, r *http.Request)
+		args
+		expectedParams map[string]interface{}
+		expectedResult cdcexchange.CreateWithdrawalResult
+	}{
+		{
+			name: "successfully creates a withdrawal with all params",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency:   currency,
+					Amount:     decimal.RequireFromString("10.5"),
+					Address:    address,
+					ClientWid:  clientWid,
+					AddressTag: addressTag,
+					NetworkId:  networkID,
+				},
+			},
+			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateWithdrawal)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodCreateWithdrawal, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Equal(t, currency, body.Params["currency"])
+				assert.Equal(t, "10.5", body.Params["amount"])
+				assert.Equal(t, address, body.Params["address"])
+				assert.Equal(t, clientWid, body.Params["client_wid"])
+				assert.Equal(t, addressTag, body.Params["address_tag"])
+				assert.Equal(t, string(networkID), body.Params["network_id"])
+
+				res := fmt.Sprintf(`{
+							"id": 0,
+							"method":"",
+							"code":0,
+							"result":{
+								"id":2220,
+								"amount":10.5,
+								"fee":0.25,
+								"symbol":"XRP",
+								"address":"some address",
+								"client_wid":"some client wid",
+								"create_time":%d,
+								"network_id":"XRP"
+							}
+						}`, now.UnixMilli())
+
+				_, err := w.Write([]byte(res))
+				require.NoError(t, err)
+			},
+			expectedParams: map[string]interface{}{
+				"currency":    currency,
+				"amount":      "10.5",
+				"address":     address,
+				"client_wid":  clientWid,
+				"address_tag": addressTag,
+				"network_id":  networkID,
+			},
+			expectedResult: cdcexchange.CreateWithdrawalResult{
+				Id:         2220,
+				Amount:     decimal.RequireFromString("10.5"),
+				Fee:        decimal.RequireFromString("0.25"),
+				Symbol:     currency,
+				Address:    address,
+				ClientWid:  clientWid,
+				CreateTime: cdctime.Time(now),
+				NetworkId:  networkID,
+			},
+		},
+		{
+			name: "successfully creates a withdrawal with mandatory params",
+			args: args{
+				req: cdcexchange.CreateWithdrawalRequest{
+					Currency: currency,
+					Amount:   decimal.RequireFromString("10.5"),
+					Address:  address,
+				},
+			},
+			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateWithdrawal)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodCreateWithdrawal, body.Method)
+				assert.Equal(t, currency, body.Params["currency"])
+				assert.Equal(t, "10.5", body.Params["amount"])
+				assert.Equal(t, address, body.Params["address"])
+				assert.NotContains(t, body.Params, "client_wid")
+				assert.NotContains(t, body.Params, "address_tag")
+				assert.NotContains(t, body.Params, "network_id")
+
+				_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{"id":2221,"amount":10.5,"fee":0.25,"symbol":"XRP","address":"some address"}}`))
+				require.NoError(t, err)
+			},
+			expectedParams: map[string]interface{}{
+				"currency": currency,
+				"amount":   "10.5",
+				"address":  address,
+			},
+			expectedResult: cdcexchange.CreateWithdrawalResult{
+				Id:      2221,
+				Amount:  decimal.RequireFromString("10.5"),
+				Fee:     decimal.RequireFromString("0.25"),
+				Symbol:  currency,
+				Address: address,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(tt.handlerFunc))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodCreateWithdrawal,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			res, err := client.CreateWithdrawal(ctx, tt.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, *res)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cdcexchange
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// DecimalFromFloat converts f to the shortest decimal that round-trips to f, i.e. which parses back to the same float64
+// (e.g. 0.1 is converted to 0.1, not its exact binary value 0.1000000000000000055...).
+//
+// It can be used when migrating code which previously set float64 prices, quantities & amounts.
+func DecimalFromFloat(f float64) (decimal.Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return decimal.Decimal{}, errors.InvalidParameterError{Parameter: "f", Reason: "must be a finite number"}
+	}
+
+	return decimal.NewFromFloat(f), nil
+}
+
+// MustDecimalFromFloat is like DecimalFromFloat but panics if f is NaN or infinite.
+func MustDecimalFromFloat(f float64) decimal.Decimal {
+	d, err := DecimalFromFloat(f)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
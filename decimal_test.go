@@ -0,0 +1,57 @@
+package cdcexchange_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+func TestDecimalFromFloat(t *testing.T) {
+	tests := []struct {
+		name        string
+		f           float64
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "converts float to shortest exact decimal",
+			f:        0.1,
+			expected: "0.1",
+		},
+		{
+			name:     "converts small float without exponent",
+			f:        0.00001,
+			expected: "0.00001",
+		},
+		{
+			name:        "returns error given NaN",
+			f:           math.NaN(),
+			expectedErr: errors.InvalidParameterError{Parameter: "f", Reason: "must be a finite number"},
+		},
+		{
+			name:        "returns error given infinity",
+			f:           math.Inf(1),
+			expectedErr: errors.InvalidParameterError{Parameter: "f", Reason: "must be a finite number"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := cdcexchange.DecimalFromFloat(tt.f)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Panics(t, func() { cdcexchange.MustDecimalFromFloat(tt.f) })
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d.String())
+			assert.Equal(t, tt.expected, cdcexchange.MustDecimalFromFloat(tt.f).String())
+		})
+	}
+}
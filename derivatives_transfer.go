@@ -0,0 +1,116 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodDerivativesTransfer = "private/deriv/transfer"
+
+	DerivativesAccountSpot        DerivativesAccountType = "SPOT"
+	DerivativesAccountDerivatives DerivativesAccountType = "DERIVATIVES"
+)
+
+type (
+	// DerivativesAccountType is the account which funds are transferred from/to (SPOT/DERIVATIVES).
+	DerivativesAccountType string
+
+	// DerivativesTransferRequest is the request params sent for the private/deriv/transfer API.
+	DerivativesTransferRequest struct {
+		// Currency is the currency symbol to transfer (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// From is the account the funds are transferred from.
+		From DerivativesAccountType `json:"from"`
+		// To is the account the funds are transferred to.
+		To DerivativesAccountType `json:"to"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// DerivativesTransferResponse is the base response returned from the private/deriv/transfer API.
+	DerivativesTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// DerivativesTransfer transfers funds between the spot wallet & the derivatives account.
+//
+// Method: private/deriv/transfer
+func (c *Client) DerivativesTransfer(ctx context.Context, req DerivativesTransferRequest) error {
+	if req.Currency == "" {
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	}
+	if req.From != DerivativesAccountSpot && req.From != DerivativesAccountDerivatives {
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "must be SPOT or DERIVATIVES"}
+	}
+	if req.To != DerivativesAccountSpot && req.To != DerivativesAccountDerivatives {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "must be SPOT or DERIVATIVES"}
+	}
+	if req.From == req.To {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be the same as req.From"}
+	}
+	if !req.Amount.IsPositive() {
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["currency"] = req.Currency
+	params["from"] = req.From
+	params["to"] = req.To
+	params["amount"] = req.Amount.String()
+
+	var derivativesTransferResponse DerivativesTransferResponse
+	err := c.retry(ctx, false, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodDerivativesTransfer,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodDerivativesTransfer,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		derivativesTransferResponse = DerivativesTransferResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodDerivativesTransfer, &derivativesTransferResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, derivativesTransferResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_DerivativesTransfer_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		currency = "USDT"
+	)
+	var (
+		testErr = errors.New("some error")
+		amount  = decimal.RequireFromString("100.5")
+	)
+
+	type args struct {
+		req cdcexchange.DerivativesTransferRequest
+	}
+	validArgs := args{
+		req: cdcexchange.DerivativesTransferRequest{
+			Currency: currency,
+			From:     cdcexchange.DerivativesAccountSpot,
+			To:       cdcexchange.DerivativesAccountDerivatives,
+			Amount:   amount,
+		},
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when currency is empty",
+			args: args{
+				req: cdcexchange.DerivativesTransferRequest{},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Currency",
+				Reason:    "cannot be empty",
+			},
+		},
+		{
+			name: "returns error when from is invalid",
+			args: args{
+				req: cdcexchange.DerivativesTransferRequest{Currency: currency, From: "FUTURES"},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.From",
+				Reason:    "must be SPOT or DERIVATIVES",
+			},
+		},
+		{
+			name: "returns error when to is invalid",
+			args: args{
+				req: cdcexchange.DerivativesTransferRequest{Currency: currency, From: cdcexchange.DerivativesAccountSpot},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.To",
+				Reason:    "must be SPOT or DERIVATIVES",
+			},
+		},
+		{
+			name: "returns error when from & to are the same",
+			args: args{
+				req: cdcexchange.DerivativesTransferRequest{Currency: currency, From: cdcexchange.DerivativesAccountSpot, To: cdcexchange.DerivativesAccountSpot},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.To",
+				Reason:    "cannot be the same as req.From",
+			},
+		},
+		{
+			name: "returns error when amount is not positive",
+			args: args{
+				req: cdcexchange.DerivativesTransferRequest{Currency: currency, From: cdcexchange.DerivativesAccountDerivatives, To: cdcexchange.DerivativesAccountSpot},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Amount",
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodDerivativesTransfer,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": currency,
+						"from":     cdcexchange.DerivativesAccountSpot,
+						"to":       cdcexchange.DerivativesAccountDerivatives,
+						"amount":   amount.String(),
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			err = client.DerivativesTransfer(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_DerivativesTransfer_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		currency = "USDT"
+	)
+	var (
+		now    = time.Now().Round(time.Second)
+		amount = decimal.RequireFromString("100.5")
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodDerivativesTransfer)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodDerivativesTransfer, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, currency, body.Params["currency"])
+		assert.Equal(t, string(cdcexchange.DerivativesAccountSpot), body.Params["from"])
+		assert.Equal(t, string(cdcexchange.DerivativesAccountDerivatives), body.Params["to"])
+		assert.Equal(t, amount.String(), body.Params["amount"])
+
+		res := `{"id":0,"method":"","code":0}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	req := cdcexchange.DerivativesTransferRequest{
+		Currency: currency,
+		From:     cdcexchange.DerivativesAccountSpot,
+		To:       cdcexchange.DerivativesAccountDerivatives,
+		Amount:   amount,
+	}
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodDerivativesTransfer,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"currency": currency,
+			"from":     cdcexchange.DerivativesAccountSpot,
+			"to":       cdcexchange.DerivativesAccountDerivatives,
+			"amount":   amount.String(),
+		},
+	}).Return(signature, nil)
+
+	err = client.DerivativesTransfer(ctx, req)
+	require.NoError(t, err)
+}
@@ -42,6 +42,19 @@ var (
 	ErrMGBlockedBorrow           = errors.New("borrow has been suspended. please try again later")
 	ErrMGBlockedNewOrder         = errors.New("placing new order has been suspended. please try again later")
 	ErrMGCreditLineNotMaintained = errors.New("please ensure your credit line is maintained and try again later")
+
+	ErrInsufficientBookDepth = errors.New("order book does not have enough depth to fill quantity")
+
+	ErrWithdrawalNotAllowed    = errors.New("withdrawal destination is not in the allowlist")
+	ErrWithdrawalLimitExceeded = errors.New("withdrawal amount exceeds the limit")
+	ErrWithdrawalNotConfirmed  = errors.New("withdrawal was not confirmed")
+
+	ErrSubAccountNotFound = errors.New("sub-account not found")
+
+	ErrOrderNotTracked        = errors.New("order is not tracked")
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+
+	ErrIncompleteOrderList = errors.New("result_list does not contain a result for each order")
 )
 
 // InvalidParameterError is returned when a required parameter is passed that is invalid.
@@ -54,6 +67,37 @@ func (ipe InvalidParameterError) Error() string {
 	return fmt.Sprintf("invalid parameter: %s %s", ipe.Parameter, ipe.Reason)
 }
 
+// ValidationError is returned when a request fails client-side validation before being sent.
+// Err is the error the API would have responded with (e.g. ErrMinQuantityViolated).
+type ValidationError struct {
+	Parameter string
+	Reason    string
+	Err       error
+}
+
+func (ve ValidationError) Error() string {
+	return fmt.Sprintf("invalid parameter: %s %s: %v", ve.Parameter, ve.Reason, ve.Err)
+}
+
+func (ve ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// WithdrawalGuardError is returned when a withdrawal is blocked by the Client's withdrawal guard before being sent.
+// Err is the rule which was violated (e.g. ErrWithdrawalLimitExceeded).
+type WithdrawalGuardError struct {
+	Reason string
+	Err    error
+}
+
+func (wge WithdrawalGuardError) Error() string {
+	return fmt.Sprintf("withdrawal blocked: %s: %v", wge.Reason, wge.Err)
+}
+
+func (wge WithdrawalGuardError) Unwrap() error {
+	return wge.Err
+}
+
 // ResponseError is returned when an error is returned from the API.
 type ResponseError struct {
 	Code           int64
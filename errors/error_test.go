@@ -427,3 +427,17 @@ func TestNewResponseError_Success(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationError(t *testing.T) {
+	err := ValidationError{Parameter: "req.Price", Reason: "must be a multiple of 0.01", Err: ErrInvalidPricePrecision}
+
+	assert.Equal(t, "invalid parameter: req.Price must be a multiple of 0.01: too many decimal places for price", err.Error())
+	assert.True(t, errors.Is(err, ErrInvalidPricePrecision))
+}
+
+func TestWithdrawalGuardError(t *testing.T) {
+	err := WithdrawalGuardError{Reason: "BTC 24h limit is 1", Err: ErrWithdrawalLimitExceeded}
+
+	assert.Equal(t, "withdrawal blocked: BTC 24h limit is 1: withdrawal amount exceeds the limit", err.Error())
+	assert.True(t, errors.Is(err, ErrWithdrawalLimitExceeded))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
 )
@@ -30,13 +32,13 @@ type (
 	// Account represents balance details of a specific token.
 	Account struct {
 		// Balance is the total balance (Available + Order + Stake).
-		Balance float64 `json:"balance"`
+		Balance decimal.Decimal `json:"balance"`
 		// Available is the available balance (e.g. not in orders, or locked, etc.).
-		Available float64 `json:"available"`
+		Available decimal.Decimal `json:"available"`
 		// Order is the balance locked in orders.
-		Order float64 `json:"order"`
+		Order decimal.Decimal `json:"order"`
 		// Stake is the balance locked for staking (typically only used for CRO).
-		Stake float64 `json:"stake"`
+		Stake decimal.Decimal `json:"stake"`
 		// Currency is the symbol for the currency (e.g. CRO).
 		Currency string `json:"currency"`
 	}
@@ -48,46 +50,55 @@ type (
 //
 // Method: private/get-account-summary
 func (c *Client) GetAccountSummary(ctx context.Context, currency string) ([]Account, error) {
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	// if currency is omitted, ALL currencies are returned.
 	if currency != "" {
 		params["currency"] = currency
 	}
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetAccountSummary,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
+	var accountSummaryResponse AccountSummaryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetAccountSummary,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetAccountSummary,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
 
-	var accountSummaryResponse AccountSummaryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetAccountSummary, &accountSummaryResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetAccountSummary,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		accountSummaryResponse = AccountSummaryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetAccountSummary, &accountSummaryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, accountSummaryResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, accountSummaryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return accountSummaryResponse.Result.Accounts, nil
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -144,6 +145,13 @@ func TestClient_GetAccountSummary_Success(t *testing.T) {
 		signature = "some signature"
 	)
 	now := time.Now()
+	account := cdcexchange.Account{
+		Balance:   decimal.RequireFromString("10.5"),
+		Available: decimal.RequireFromString("7.25"),
+		Order:     decimal.RequireFromString("3.25"),
+		Stake:     decimal.RequireFromString("0"),
+		Currency:  currency,
+	}
 
 	type args struct {
 		currency string
@@ -178,13 +186,13 @@ func TestClient_GetAccountSummary_Success(t *testing.T) {
 				res := cdcexchange.AccountSummaryResponse{
 					BaseResponse: api.BaseResponse{},
 					Result: cdcexchange.AccountSummaryResult{
-						Accounts: []cdcexchange.Account{{Currency: currency}},
+						Accounts: []cdcexchange.Account{account},
 					},
 				}
 
 				require.NoError(t, json.NewEncoder(w).Encode(res))
 			},
-			expectedResult: []cdcexchange.Account{{Currency: currency}},
+			expectedResult: []cdcexchange.Account{account},
 		},
 		{
 			name: "returns account summary for currency",
@@ -209,13 +217,13 @@ func TestClient_GetAccountSummary_Success(t *testing.T) {
 				res := cdcexchange.AccountSummaryResponse{
 					BaseResponse: api.BaseResponse{},
 					Result: cdcexchange.AccountSummaryResult{
-						Accounts: []cdcexchange.Account{{Currency: currency}},
+						Accounts: []cdcexchange.Account{account},
 					},
 				}
 
 				require.NoError(t, json.NewEncoder(w).Encode(res))
 			},
-			expectedResult: []cdcexchange.Account{{Currency: currency}},
+			expectedResult: []cdcexchange.Account{account},
 		},
 	}
 	for _, tt := range tests {
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
+
+	"github.com/shopspring/decimal"
 
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/time"
@@ -33,14 +36,30 @@ type (
 
 	// BookData is the result returned from the public/get-book API.
 	BookData struct {
-		// Bids is an array of bids.
-		// [0] = Price, [1] = Quantity, [2] = Number of Orders.
-		Bids [][]string `json:"bids"`
-		// Asks is an array of asks.
-		// [0] = Price, [1] = Quantity, [2] = Number of Orders.
-		Asks [][]string `json:"asks"`
+		// Bids is an array of bids, in the order returned by the Exchange.
+		Bids []PriceLevel `json:"bids"`
+		// Asks is an array of asks, in the order returned by the Exchange.
+		Asks []PriceLevel `json:"asks"`
 		// Timestamp is the timestamp of the data.
 		Timestamp time.Time `json:"t"`
+		// UpdateSequence is the sequence number of the update.
+		UpdateSequence int64 `json:"u,omitempty"`
+		// PreviousUpdateSequence is the sequence number of the update preceding this one.
+		// It is 0 when the data is a full snapshot of the book rather than a delta.
+		PreviousUpdateSequence int64 `json:"pu,omitempty"`
+	}
+
+	// PriceLevel is the aggregated quantity of all orders at a single price.
+	//
+	// The Exchange sends levels as [price, quantity, number of orders],
+	// the number of orders is omitted by some channels.
+	PriceLevel struct {
+		// Price is the price of the level.
+		Price decimal.Decimal
+		// Quantity is the total quantity at the price.
+		Quantity decimal.Decimal
+		// OrderCount is the number of orders at the price (0 if not provided).
+		OrderCount int
 	}
 )
 
@@ -48,41 +67,138 @@ type (
 //
 // Method: public/get-book
 func (c *Client) GetBook(ctx context.Context, instrument string, depth int) (*BookResult, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.requester.BaseURL, api.V2, methodGetBook), nil)
+	var bookResponse BookResponse
+	err := c.retry(ctx, true, func() error {
+		if err := c.requester.Wait(ctx, methodGetBook); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.requester.BaseURL, api.V2, methodGetBook), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		q := req.URL.Query()
+
+		q.Add("instrument_name", instrument)
+
+		if depth > 0 {
+			q.Add("depth", fmt.Sprintf("%d", depth))
+		}
+
+		req.URL.RawQuery = q.Encode()
+
+		sent := c.clock.Now()
+		res, err := c.requester.Client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to do request: %w", err)
+		}
+		defer res.Body.Close()
+
+		resBytes, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		bookResponse = BookResponse{}
+		if err := json.Unmarshal(resBytes, &bookResponse); err != nil {
+			return fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(res.StatusCode, bookResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		if len(bookResponse.Result.Data) > 0 {
+			c.observeServerTime(sent, c.clock.Now(), bookResponse.Result.Data[0].Timestamp.Time())
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	q := req.URL.Query()
+	return &bookResponse.Result, nil
+}
 
-	q.Add("instrument_name", instrument)
+// Bids returns the bids of all book data, best (highest price) first.
+func (r BookResult) Bids() []PriceLevel {
+	var bids []PriceLevel
+	for _, data := range r.Data {
+		bids = append(bids, data.Bids...)
+	}
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
 
-	if depth > 0 {
-		q.Add("depth", fmt.Sprintf("%d", depth))
+	return bids
+}
+
+// Asks returns the asks of all book data, best (lowest price) first.
+func (r BookResult) Asks() []PriceLevel {
+	var asks []PriceLevel
+	for _, data := range r.Data {
+		asks = append(asks, data.Asks...)
 	}
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
 
-	req.URL.RawQuery = q.Encode()
+	return asks
+}
 
-	res, err := c.requester.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to do request: %w", err)
+// BestBid returns the highest bid, false is returned if there are no bids.
+func (r BookResult) BestBid() (PriceLevel, bool) {
+	bids := r.Bids()
+	if len(bids) == 0 {
+		return PriceLevel{}, false
 	}
-	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	return bids[0], true
+}
+
+// BestAsk returns the lowest ask, false is returned if there are no asks.
+func (r BookResult) BestAsk() (PriceLevel, bool) {
+	asks := r.Asks()
+	if len(asks) == 0 {
+		return PriceLevel{}, false
 	}
 
-	var bookResponse BookResponse
-	if err := json.Unmarshal(resBytes, &bookResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	return asks[0], true
+}
+
+// UnmarshalJSON decodes a price level from either its 2 element [price, quantity]
+// or 3 element [price, quantity, number of orders] array form.
+// Values can be either strings or numbers.
+func (l *PriceLevel) UnmarshalJSON(data []byte) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
 	}
 
-	if err := c.requester.CheckErrorResponse(res.StatusCode, bookResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+	if len(values) != 2 && len(values) != 3 {
+		return fmt.Errorf("invalid price level: expected 2 or 3 elements, got %d", len(values))
 	}
 
-	return &bookResponse.Result, nil
+	if err := l.Price.UnmarshalJSON(values[0]); err != nil {
+		return fmt.Errorf("invalid price level price: %w", err)
+	}
+	if err := l.Quantity.UnmarshalJSON(values[1]); err != nil {
+		return fmt.Errorf("invalid price level quantity: %w", err)
+	}
+
+	l.OrderCount = 0
+	if len(values) == 3 {
+		// some responses send the count as a float (e.g. 1.0).
+		var count decimal.Decimal
+		if err := count.UnmarshalJSON(values[2]); err != nil || !count.IsInteger() {
+			return fmt.Errorf("invalid price level order count: %s", values[2])
+		}
+		l.OrderCount = int(count.IntPart())
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a price level in its 3 element [price, quantity, number of orders] array form.
+func (l PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{l.Price.String(), l.Quantity.String(), fmt.Sprintf("%d", l.OrderCount)})
 }
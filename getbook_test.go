@@ -2,6 +2,7 @@ package cdcexchange_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	cdcexchange "github.com/sngyai/go-cryptocom"
 	cdcerrors "github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
 )
 
 func TestClient_GetBook_Error(t *testing.T) {
@@ -139,19 +141,27 @@ func TestClient_GetBook_Success(t *testing.T) {
 							"method":"",
 							"code":0,
 							"result":{
-								"bids":[[9668.44,0.006325,1.0]],
-								"asks":[[9697.0,0.68251,1.0]],
-								"t": %d
+								"instrument_name":"%s",
+								"depth":%d,
+								"data":[{
+									"bids":[["9668.44","0.006325","1"],[9668.1,0.5,2.0]],
+									"asks":[["9697.0","0.68251"]],
+									"t": %d
+								}]
 							}
-						}`, now.UnixMilli())
+						}`, instrument, depth, now.UnixMilli())
 
 				_, err := w.Write([]byte(res))
 				require.NoError(t, err)
 			},
 			expectedResult: cdcexchange.BookResult{
-				//Bids:      [][]float64{{9668.44, 0.006325, 1.0}},
-				//Asks:      [][]float64{{9697.0, 0.68251, 1.0}},
-				//Timestamp: cdctime.Time(now),
+				Depth:          depth,
+				InstrumentName: instrument,
+				Data: []cdcexchange.BookData{{
+					Bids:      []cdcexchange.PriceLevel{priceLevel("9668.44", "0.006325", 1), priceLevel("9668.1", "0.5", 2)},
+					Asks:      []cdcexchange.PriceLevel{priceLevel("9697.0", "0.68251", 0)},
+					Timestamp: cdctime.Time(now),
+				}},
 			},
 		},
 	}
@@ -181,3 +191,44 @@ func TestClient_GetBook_Success(t *testing.T) {
 		})
 	}
 }
+
+func TestBookResult(t *testing.T) {
+	result := cdcexchange.BookResult{
+		Data: []cdcexchange.BookData{{
+			Bids: []cdcexchange.PriceLevel{priceLevel("99", "1", 1), priceLevel("100.5", "2", 1)},
+			Asks: []cdcexchange.PriceLevel{priceLevel("102", "1", 1), priceLevel("101", "3", 2)},
+		}},
+	}
+
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("100.5", "2", 1), priceLevel("99", "1", 1)}, result.Bids())
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("101", "3", 2), priceLevel("102", "1", 1)}, result.Asks())
+
+	bid, ok := result.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, priceLevel("100.5", "2", 1), bid)
+
+	ask, ok := result.BestAsk()
+	require.True(t, ok)
+	assert.Equal(t, priceLevel("101", "3", 2), ask)
+
+	_, ok = cdcexchange.BookResult{}.BestBid()
+	assert.False(t, ok)
+}
+
+func TestPriceLevel_UnmarshalJSON_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "returns error given too few elements", data: `["1"]`},
+		{name: "returns error given too many elements", data: `["1","2","3","4"]`},
+		{name: "returns error given invalid price", data: `["a","2"]`},
+		{name: "returns error given fractional order count", data: `["1","2","1.5"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var level cdcexchange.PriceLevel
+			assert.Error(t, json.Unmarshal([]byte(tt.data), &level))
+		})
+	}
+}
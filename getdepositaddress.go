@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
+	"github.com/sngyai/go-cryptocom/internal/time"
 )
 
 const (
@@ -14,16 +16,8 @@ const (
 
 type (
 	// GetDepositAddressRequest is the request params sent for the private/get-deposit-address API.
-	//
-	// The maximum duration between Start and EndTime is 24 hours.
-	//
-	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
-	//
-	// For users looking to pull longer historical deposit data, users can create a loop to make a request
-	// for each 24-period from the desired start to end time.
 	GetDepositAddressRequest struct {
-		// Currency represents the currency symbol for the deposits (e.g. BTC or ETH).
-		// if Currency is omitted, all currencies will be returned.
+		// Currency represents the currency symbol for the deposit addresses (e.g. BTC or ETH).
 		Currency string `json:"currency"`
 	}
 
@@ -37,69 +31,75 @@ type (
 
 	// GetDepositAddressResult is the result returned from the private/get-deposit-address API.
 	GetDepositAddressResult struct {
-		// DepositList is the array of deposits.
+		// DepositAddressList is the array of deposit addresses.
 		DepositAddressList []DepositAddress `json:"deposit_address_list"`
 	}
 
 	DepositAddress struct {
-		Currency   string `json:"currency"`
-		CreateTime int64  `json:"create_time"`
-		Id         string `json:"id"`
-		Address    string `json:"address"`
-		Status     string `json:"status"`
-		Network    string `json:"network"`
+		Currency   string    `json:"currency"`
+		CreateTime time.Time `json:"create_time"`
+		Id         string    `json:"id"`
+		Address    string    `json:"address"`
+		Status     string    `json:"status"`
+		Network    string    `json:"network"`
 	}
 )
 
-// GetDepositAddress gets the deposit address for a particular instrument.
-//
-// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
-// If paging is used, enumerate each page (starting with 0) until an empty deposit_list array appears in the response.
-//
-// req.Timeframe can be left blank to get deposits for all instruments.
+// GetDepositAddress gets the deposit addresses of a particular currency (one per network).
 //
 // Method: private/get-deposit-address
 func (c *Client) GetDepositAddress(ctx context.Context, req GetDepositAddressRequest) ([]DepositAddress, error) {
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
-
-	if req.Currency != "" {
-		params["currency"] = req.Currency
+	if req.Currency == "" {
+		return nil, errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
 	}
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetDepositAddress,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
+	params := make(map[string]interface{})
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetDepositAddress,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+	params["currency"] = req.Currency
 
-	var GetDepositAddressResponse GetDepositAddressResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetDepositAddress, &GetDepositAddressResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+	var getDepositAddressResponse GetDepositAddressResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetDepositAddress,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetDepositAddress,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getDepositAddressResponse = GetDepositAddressResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetDepositAddress, &getDepositAddressResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, GetDepositAddressResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, getDepositAddressResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return GetDepositAddressResponse.Result.DepositAddressList, nil
+	return getDepositAddressResponse.Result.DepositAddressList, nil
 }
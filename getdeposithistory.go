@@ -5,16 +5,27 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
 )
 
 const (
 	methodGetDepositHistory = "private/get-deposit-history"
+
+	DepositStatusNotArrived DepositStatus = "0"
+	DepositStatusArrived    DepositStatus = "1"
+	DepositStatusFailed     DepositStatus = "2"
+	DepositStatusPending    DepositStatus = "3"
 )
 
 type (
+	// DepositStatus is the current status of the deposit, represented by the Exchange's numeric code.
+	DepositStatus string
+
 	// GetDepositHistoryRequest is the request params sent for the private/get-deposit-history API.
 	//
 	// The maximum duration between Start and End is 24 hours.
@@ -40,8 +51,9 @@ type (
 		// Page represents the page number (for pagination)
 		// (0-based)
 		Page int `json:"page"`
-
-		Status string `json:"status"`
+		// Status represents the status of the deposits to return.
+		// if Status is omitted, deposits of all statuses will be returned.
+		Status DepositStatus `json:"status"`
 	}
 
 	// GetDepositHistoryResponse is the base response returned from the private/get-deposit-history API.
@@ -58,24 +70,66 @@ type (
 		DepositList []Deposit `json:"deposit_list"`
 	}
 
+	// Deposit represents the details of a specific deposit.
 	Deposit struct {
-		Currency   string  `json:"currency"`
-		Fee        float64 `json:"fee"`
-		CreateTime int64   `json:"create_time"`
-		Id         string  `json:"id"`
-		UpdateTime int64   `json:"update_time"`
-		Amount     float64 `json:"amount"`
-		Address    string  `json:"address"`
-		Status     string  `json:"status"`
+		// Currency is the currency symbol of the deposit (e.g. BTC or ETH).
+		Currency string `json:"currency"`
+		// Fee is the deposit fee.
+		Fee decimal.Decimal `json:"fee"`
+		// CreateTime is the deposit creation time.
+		CreateTime cdctime.Time `json:"create_time"`
+		// Id is the unique identifier for the deposit.
+		Id string `json:"id"`
+		// UpdateTime is the deposit update time.
+		UpdateTime cdctime.Time `json:"update_time"`
+		// Amount is the deposit amount.
+		Amount decimal.Decimal `json:"amount"`
+		// Address is the address the deposit was sent to.
+		Address string `json:"address"`
+		// Status is the status of the deposit.
+		Status DepositStatus `json:"status"`
 	}
 )
 
-// GetDepositHistory gets the deposit history for a particular instrument.
+// String returns the name of the status (e.g. ARRIVED), or its numeric code if it is not recognised.
+func (s DepositStatus) String() string {
+	switch s {
+	case DepositStatusNotArrived:
+		return "NOT_ARRIVED"
+	case DepositStatusArrived:
+		return "ARRIVED"
+	case DepositStatusFailed:
+		return "FAILED"
+	case DepositStatusPending:
+		return "PENDING"
+	default:
+		return string(s)
+	}
+}
+
+// IsTerminal returns whether the deposit can no longer change status.
+func (s DepositStatus) IsTerminal() bool {
+	return s == DepositStatusArrived || s == DepositStatusFailed
+}
+
+// UnmarshalJSON accepts the status code as either a string or a number.
+func (s *DepositStatus) UnmarshalJSON(data []byte) error {
+	code, err := unmarshalStatusCode(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal deposit status: %w", err)
+	}
+
+	*s = DepositStatus(code)
+	return nil
+}
+
+// GetDepositHistory gets the deposit history for a particular currency.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
 // If paging is used, enumerate each page (starting with 0) until an empty deposit_list array appears in the response.
+// IterateDepositHistory can be used to do this automatically.
 //
-// req.Timeframe can be left blank to get deposits for all instruments.
+// req.Currency can be left blank to get deposits for all currencies.
 //
 // Method: private/get-deposit-history
 func (c *Client) GetDepositHistory(ctx context.Context, req GetDepositHistoryRequest) ([]Deposit, error) {
@@ -85,12 +139,11 @@ func (c *Client) GetDepositHistory(ctx context.Context, req GetDepositHistoryReq
 	if req.PageSize > 200 {
 		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be greater than 200"}
 	}
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	if req.Currency != "" {
 		params["currency"] = req.Currency
@@ -106,38 +159,51 @@ func (c *Client) GetDepositHistory(ctx context.Context, req GetDepositHistoryReq
 	}
 	params["page"] = req.Page
 	if req.Status != "" {
-		params["status"] = req.Status
-	}
-
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetDepositHistory,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
-
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetDepositHistory,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
+		params["status"] = string(req.Status)
 	}
 
 	var getDepositHistoryResponse GetDepositHistoryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetDepositHistory, &getDepositHistoryResponse)
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetDepositHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetDepositHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getDepositHistoryResponse = GetDepositHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetDepositHistory, &getDepositHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getDepositHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
-
-	if err := c.requester.CheckErrorResponse(statusCode, getDepositHistoryResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil, err
 	}
 
 	return getDepositHistoryResponse.Result.DepositList, nil
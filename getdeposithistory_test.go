@@ -0,0 +1,367 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+func TestClient_GetDepositHistory_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+	now := time.Now()
+
+	type args struct {
+		req cdcexchange.GetDepositHistoryRequest
+	}
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		responseErr  error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when page size is less than 0",
+			args: args{
+				req: cdcexchange.GetDepositHistoryRequest{
+					PageSize: -1,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Limit",
+				Reason:    "cannot be less than 0",
+			},
+		},
+		{
+			name: "returns error when page size is greater than 200",
+			args: args{
+				req: cdcexchange.GetDepositHistoryRequest{
+					PageSize: 201,
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.Limit",
+				Reason:    "cannot be greater than 200",
+			},
+		},
+		{
+			name: "returns error when end is before start",
+			args: args{
+				req: cdcexchange.GetDepositHistoryRequest{
+					Start: now,
+					End:   now.Add(-time.Second),
+				},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.End",
+				Reason:    "cannot be before req.Start",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			responseErr: nil,
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetDepositHistory,
+					Timestamp: now.UnixMilli(),
+					Params:    map[string]interface{}{"page": 0},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.GetDepositHistory(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetDepositHistory_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		currency  = "BTC"
+		depositID = "some deposit id"
+		address   = "some address"
+	)
+	now := time.Now().Round(time.Second)
+
+	type args struct {
+		req cdcexchange.GetDepositHistoryRequest
+	}
+	tests := []struct {
+		name        string
+		handlerFunc func(w http.ResponseWriter, r *http.Request)
+		args
+		expectedParams map[string]interface{}
+		expectedResult []cdcexchange.Deposit
+	}{
+		{
+			name: "successfully gets deposits for a currency",
+			args: args{
+				req: cdcexchange.GetDepositHistoryRequest{
+					Currency: currency,
+					PageSize: 100,
+					Page:     1,
+					Status:   cdcexchange.DepositStatusArrived,
+				},
+			},
+			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetDepositHistory)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodGetDepositHistory, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Equal(t, currency, body.Params["currency"])
+				assert.Equal(t, float64(100), body.Params["page_size"])
+				assert.Equal(t, float64(1), body.Params["page"])
+				assert.Equal(t, "1", body.Params["status"])
+
+				res := fmt.Sprintf(`{
+							"id": 0,
+							"method":"",
+							"code":0,
+							"result":{
+								"deposit_list":[
+									{
+										"currency":"BTC",
+										"fee":"0.0001",
+										"create_time":%d,
+										"id":"some deposit id",
+										"update_time":%d,
+										"amount":"1.5",
+										"address":"some address",
+										"status":"1"
+									}
+								]
+							}
+						}`, now.UnixMilli(), now.UnixMilli())
+
+				_, err := w.Write([]byte(res))
+				require.NoError(t, err)
+			},
+			expectedParams: map[string]interface{}{
+				"currency":  currency,
+				"page_size": 100,
+				"page":      1,
+				"status":    "1",
+			},
+			expectedResult: []cdcexchange.Deposit{
+				{
+					Currency:   currency,
+					Fee:        decimal.RequireFromString("0.0001"),
+					CreateTime: cdctime.Time(now),
+					Id:         depositID,
+					UpdateTime: cdctime.Time(now),
+					Amount:     decimal.RequireFromString("1.5"),
+					Address:    address,
+					Status:     cdcexchange.DepositStatusArrived,
+				},
+			},
+		},
+		{
+			name: "successfully gets deposits for all currencies between timestamps",
+			args: args{
+				req: cdcexchange.GetDepositHistoryRequest{
+					Start: now,
+					End:   now.Add(time.Hour),
+				},
+			},
+			handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, cdcexchange.MethodGetDepositHistory)
+				t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				assert.Equal(t, cdcexchange.MethodGetDepositHistory, body.Method)
+				assert.Equal(t, id, body.ID)
+				assert.Equal(t, apiKey, body.APIKey)
+				assert.Equal(t, now.UnixMilli(), body.Nonce)
+				assert.Equal(t, signature, body.Signature)
+				assert.Equal(t, float64(0), body.Params["page"])
+				assert.Equal(t, float64(now.UnixMilli()), body.Params["start_ts"])
+				assert.Equal(t, float64(now.Add(time.Hour).UnixMilli()), body.Params["end_ts"])
+
+				_, err := w.Write([]byte(`{"id":0,"method":"","code":0,"result":{"deposit_list":[]}}`))
+				require.NoError(t, err)
+			},
+			expectedParams: map[string]interface{}{
+				"start_ts": now.UnixMilli(),
+				"end_ts":   now.Add(time.Hour).UnixMilli(),
+				"page":     0,
+			},
+			expectedResult: []cdcexchange.Deposit{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(tt.handlerFunc))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetDepositHistory,
+				Timestamp: now.UnixMilli(),
+				Params:    tt.expectedParams,
+			}).Return(signature, nil)
+
+			res, err := client.GetDepositHistory(ctx, tt.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, res)
+		})
+	}
+}
+
+func TestDepositStatus(t *testing.T) {
+	tests := []struct {
+		json       string
+		status     cdcexchange.DepositStatus
+		name       string
+		isTerminal bool
+	}{
+		{json: `"0"`, status: cdcexchange.DepositStatusNotArrived, name: "NOT_ARRIVED", isTerminal: false},
+		{json: `"1"`, status: cdcexchange.DepositStatusArrived, name: "ARRIVED", isTerminal: true},
+		{json: `2`, status: cdcexchange.DepositStatusFailed, name: "FAILED", isTerminal: true},
+		{json: `3`, status: cdcexchange.DepositStatusPending, name: "PENDING", isTerminal: false},
+		{json: `"9"`, status: cdcexchange.DepositStatus("9"), name: "9", isTerminal: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status cdcexchange.DepositStatus
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &status))
+
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.name, status.String())
+			assert.Equal(t, tt.isTerminal, status.IsTerminal())
+		})
+	}
+
+	t.Run("returns error given invalid status", func(t *testing.T) {
+		var status cdcexchange.DepositStatus
+		assert.Error(t, json.Unmarshal([]byte(`"arrived"`), &status))
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/internal/api"
 )
 
@@ -41,15 +43,15 @@ type (
 		// MarginTradingEnabled represents whether margin trading is enabled for the instrument.
 		MarginTradingEnabled bool `json:"margin_trading_enabled"`
 		// MinimumOrderSize represents the minimum order size for the instrument.
-		MarginTradingEnabled5X  bool   `json:"margin_trading_enabled_5x"`
-		MarginTradingEnabled10X bool   `json:"margin_trading_enabled_10x"`
-		MaxQuantity             string `json:"max_quantity"`
-		MinQuantity             string `json:"min_quantity"`
-		MaxPrice                string `json:"max_price"`
-		MinPrice                string `json:"min_price"`
-		LastUpdateDate          int64  `json:"last_update_date"`
-		QuantityTickSize        string `json:"quantity_tick_size"`
-		PriceTickSize           string `json:"price_tick_size"`
+		MarginTradingEnabled5X  bool            `json:"margin_trading_enabled_5x"`
+		MarginTradingEnabled10X bool            `json:"margin_trading_enabled_10x"`
+		MaxQuantity             decimal.Decimal `json:"max_quantity"`
+		MinQuantity             decimal.Decimal `json:"min_quantity"`
+		MaxPrice                decimal.Decimal `json:"max_price"`
+		MinPrice                decimal.Decimal `json:"min_price"`
+		LastUpdateDate          int64           `json:"last_update_date"`
+		QuantityTickSize        decimal.Decimal `json:"quantity_tick_size"`
+		PriceTickSize           decimal.Decimal `json:"price_tick_size"`
 	}
 )
 
@@ -57,20 +59,28 @@ type (
 //
 // Method: public/get-instruments
 func (c *Client) GetInstruments(ctx context.Context) ([]Instrument, error) {
-	body := api.Request{
-		ID:     c.idGenerator.Generate(),
-		Method: methodGetInstruments,
-		Nonce:  c.clock.Now().UnixMilli(),
-	}
-
 	var instrumentsResponse InstrumentsResponse
-	statusCode, err := c.requester.Get(ctx, body, methodGetInstruments, &instrumentsResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+	err := c.retry(ctx, true, func() error {
+		body := api.Request{
+			ID:     c.idGenerator.Generate(),
+			Method: methodGetInstruments,
+			Nonce:  c.nonce(),
+		}
+
+		instrumentsResponse = InstrumentsResponse{}
+		statusCode, err := c.requester.Get(ctx, body, methodGetInstruments, &instrumentsResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, instrumentsResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, instrumentsResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return instrumentsResponse.Result.Instruments, nil
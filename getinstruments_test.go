@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -112,6 +113,15 @@ func TestClient_GetIstruments_Success(t *testing.T) {
 		instrument = "some instrument"
 	)
 	now := time.Now()
+	expectedInstrument := cdcexchange.Instrument{
+		InstrumentName:   instrument,
+		MaxQuantity:      decimal.RequireFromString("100000000"),
+		MinQuantity:      decimal.RequireFromString("0.0001"),
+		MaxPrice:         decimal.RequireFromString("1000000"),
+		MinPrice:         decimal.RequireFromString("0.01"),
+		QuantityTickSize: decimal.RequireFromString("0.0001"),
+		PriceTickSize:    decimal.RequireFromString("0.01"),
+	}
 
 	tests := []struct {
 		name           string
@@ -137,13 +147,13 @@ func TestClient_GetIstruments_Success(t *testing.T) {
 
 				res := cdcexchange.InstrumentsResponse{
 					Result: cdcexchange.InstrumentResult{
-						Instruments: []cdcexchange.Instrument{{InstrumentName: instrument}},
+						Instruments: []cdcexchange.Instrument{expectedInstrument},
 					},
 				}
 
 				require.NoError(t, json.NewEncoder(w).Encode(res))
 			},
-			expectedResult: []cdcexchange.Instrument{{InstrumentName: instrument}},
+			expectedResult: []cdcexchange.Instrument{expectedInstrument},
 		},
 	}
 	for _, tt := range tests {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
@@ -64,9 +66,9 @@ type (
 		// Side represents whether the order is buy or sell.
 		Side OrderSide `json:"side"`
 		// Price is the price specified in the order.
-		Price float64 `json:"price"`
+		Price decimal.Decimal `json:"price"`
 		// Quantity	is the quantity specified in the order.
-		Quantity float64 `json:"quantity"`
+		Quantity decimal.Decimal `json:"quantity"`
 		// OrderID is the unique identifier for the order.
 		OrderID string `json:"order_id"`
 		// ClientOID is the optional Client order ID (if provided in request when creating the order).
@@ -80,11 +82,11 @@ type (
 		// InstrumentName represents the currency pair to trade (e.g. ETH_CRO or BTC_USDT).
 		InstrumentName string `json:"instrument_name"`
 		// CumulativeQuantity is the cumulative-executed quantity (for partially filled orders).
-		CumulativeQuantity float64 `json:"cumulative_quantity"`
+		CumulativeQuantity decimal.Decimal `json:"cumulative_quantity"`
 		// CumulativeValue is the cumulative-executed value (for partially filled orders).
-		CumulativeValue float64 `json:"cumulative_value"`
+		CumulativeValue decimal.Decimal `json:"cumulative_value"`
 		// AvgPrice is the average filled price. If none is filled, 0 is returned.
-		AvgPrice float64 `json:"avg_price"`
+		AvgPrice decimal.Decimal `json:"avg_price"`
 		// FeeCurrency is the currency used for the fees (e.g. CRO).
 		FeeCurrency string `json:"fee_currency"`
 		// TimeInForce represents how long the order should be active before being cancelled.
@@ -99,13 +101,24 @@ type (
 		ExecInst ExecInst `json:"exec_inst"`
 		// TriggerPrice is the price at which the order is triggered.
 		// Used with STOP_LOSS, STOP_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
-		TriggerPrice float64 `json:"trigger_price"`
+		TriggerPrice decimal.Decimal `json:"trigger_price"`
 	}
 )
 
+// IsTerminal returns whether the order can no longer change status.
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusCancelled, OrderStatusFilled, OrderStatusRejected, OrderStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetOpenOrders gets all open orders for a particular instrument.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// IterateOpenOrders can be used to enumerate each page automatically.
 //
 // req.Timeframe can be left blank to get open orders for all instruments.
 //
@@ -118,11 +131,7 @@ func (c *Client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be greater than 200"}
 	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	if req.InstrumentName != "" {
 		params["instrument_name"] = req.InstrumentName
@@ -132,35 +141,48 @@ func (c *Client) GetOpenOrders(ctx context.Context, req GetOpenOrdersRequest) (*
 	}
 	params["page"] = req.Page
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetOpenOrders,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
-
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetOpenOrders,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
-
 	var getOpenOrdersResponse GetOpenOrdersResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOpenOrders, &getOpenOrdersResponse)
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetOpenOrders,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetOpenOrders,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getOpenOrdersResponse = GetOpenOrdersResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetOpenOrders, &getOpenOrdersResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getOpenOrdersResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
-
-	if err := c.requester.CheckErrorResponse(statusCode, getOpenOrdersResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil, err
 	}
 
 	return &getOpenOrdersResponse.Result, nil
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
@@ -44,15 +46,15 @@ type (
 		// InstrumentName represents the currency pair to trade (e.g. ETH_CRO or BTC_USDT).
 		InstrumentName string `json:"instrument_name"`
 		// Fee is the trade fee.
-		Fee float64 `json:"fee"`
+		Fee decimal.Decimal `json:"fee"`
 		// TradeID is the unique identifier for the trade.
 		TradeID string `json:"trade_id"`
 		// CreateTime is the trade creation time.
 		CreateTime time.Time `json:"create_time"`
 		// TradedPrice is the executed trade price
-		TradedPrice float64 `json:"traded_price"`
+		TradedPrice decimal.Decimal `json:"traded_price"`
 		// TradedQuantity is the executed trade quantity
-		TradedQuantity float64 `json:"traded_quantity"`
+		TradedQuantity decimal.Decimal `json:"traded_quantity"`
 		// FeeCurrency is the currency used for the fees (e.g. CRO).
 		FeeCurrency string `json:"fee_currency"`
 		// OrderID is the unique identifier for the order.
@@ -72,43 +74,52 @@ func (c *Client) GetOrderDetail(ctx context.Context, orderID string) (*GetOrderD
 		return nil, errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
 	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	params["order_id"] = orderID
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetOrderDetail,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
-
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetOrderDetail,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
-
 	var getOrderDetailResponse GetOrderDetailResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOrderDetail, &getOrderDetailResponse)
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetOrderDetail,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetOrderDetail,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getOrderDetailResponse = GetOrderDetailResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetOrderDetail, &getOrderDetailResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getOrderDetailResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
-
-	if err := c.requester.CheckErrorResponse(statusCode, getOrderDetailResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil, err
 	}
 
 	return &getOrderDetailResponse.Result, nil
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -238,11 +239,11 @@ func TestClient_GetOrderDetail_Success(t *testing.T) {
 					{
 						Side:           cdcexchange.OrderSideBuy,
 						InstrumentName: "ETH_CRO",
-						Fee:            0.007,
+						Fee:            decimal.RequireFromString("0.007"),
 						TradeID:        "371303044218155296",
 						CreateTime:     cdctime.Time(now),
-						TradedPrice:    7,
-						TradedQuantity: 7,
+						TradedPrice:    decimal.RequireFromString("7"),
+						TradedQuantity: decimal.RequireFromString("7"),
 						FeeCurrency:    "CRO",
 						OrderID:        orderID,
 					},
@@ -256,9 +257,9 @@ func TestClient_GetOrderDetail_Success(t *testing.T) {
 					UpdateTime:         cdctime.Time(now),
 					OrderType:          cdcexchange.OrderTypeLimit,
 					InstrumentName:     "ETH_CRO",
-					CumulativeQuantity: 7,
-					CumulativeValue:    7,
-					AvgPrice:           7,
+					CumulativeQuantity: decimal.RequireFromString("7"),
+					CumulativeValue:    decimal.RequireFromString("7"),
+					AvgPrice:           decimal.RequireFromString("7"),
 					FeeCurrency:        "CRO",
 					TimeInForce:        cdcexchange.TimeInForceGoodTilCancelled,
 					ExecInst:           cdcexchange.ExecInstPostOnly,
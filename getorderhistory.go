@@ -23,6 +23,7 @@ type (
 	//
 	// For users looking to pull longer historical order data, users can create a loop to make a request
 	// for each 24-period from the desired start to end time.
+	// IterateOrderHistoryInRange can be used to do this automatically.
 	GetOrderHistoryRequest struct {
 		// InstrumentName represents the currency pair for the orders (e.g. ETH_CRO or BTC_USDT).
 		// if InstrumentName is omitted, all instruments will be returned.
@@ -61,6 +62,7 @@ type (
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
 // If paging is used, enumerate each page (starting with 0) until an empty order_list array appears in the response.
+// IterateOrderHistory can be used to do this automatically.
 //
 // req.Timeframe can be left blank to get orders for all instruments.
 //
@@ -73,11 +75,7 @@ func (c *Client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be greater than 200"}
 	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	if req.InstrumentName != "" {
 		params["instrument_name"] = req.InstrumentName
@@ -93,35 +91,48 @@ func (c *Client) GetOrderHistory(ctx context.Context, req GetOrderHistoryRequest
 	}
 	params["page"] = req.Page
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetOrderHistory,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
+	var getOrderHistoryResponse GetOrderHistoryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetOrderHistory,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetOrderHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
 
-	var getOrderHistoryResponse GetOrderHistoryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetOrderHistory, &getOrderHistoryResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetOrderHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getOrderHistoryResponse = GetOrderHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetOrderHistory, &getOrderHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, getOrderHistoryResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		if err := c.requester.CheckErrorResponse(statusCode, getOrderHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return getOrderHistoryResponse.Result.OrderList, nil
@@ -7,6 +7,8 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/time"
 )
@@ -36,21 +38,21 @@ type (
 		// Instrument is the instrument name (e.g. BTC_USDT, ETH_CRO, etc).
 		Instrument string `json:"i"`
 		// BidPrice is the current best bid price, 0 if there aren't any bids.
-		BidPrice float64 `json:"b,string"`
+		BidPrice decimal.Decimal `json:"b"`
 		// AskPrice is the current best ask price, 0 if there aren't any asks.
-		AskPrice float64 `json:"k,string"`
+		AskPrice decimal.Decimal `json:"k"`
 		// LatestTradePrice is the price of the latest trade, 0 if there weren't any trades.
-		LatestTradePrice float64 `json:"a,string"`
+		LatestTradePrice decimal.Decimal `json:"a"`
 		// Timestamp is the timestamp of the data.
 		Timestamp time.Time `json:"t"`
 		// Volume24H is the total 24h traded volume.
-		Volume24H float64 `json:"v,string"`
+		Volume24H decimal.Decimal `json:"v"`
 		// PriceHigh24h is the price of the 24h highest trade, 0 if there weren't any trades.
-		PriceHigh24h float64 `json:"h,string"`
+		PriceHigh24h decimal.Decimal `json:"h"`
 		// PriceLow24h is the price of the 24h lowest trade, 0 if there weren't any trades.
-		PriceLow24h float64 `json:"l,string"`
+		PriceLow24h decimal.Decimal `json:"l"`
 		// PriceChange24h is the 24-hour price change, 0 if there weren't any trades.
-		PriceChange24h float64 `json:"c,string"`
+		PriceChange24h decimal.Decimal `json:"c"`
 	}
 )
 
@@ -60,46 +62,53 @@ type (
 //
 // Method: public/get-ticker
 func (c *Client) GetTickers(ctx context.Context, instrument string) ([]Ticker, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.requester.BaseURL, api.V2, methodGetTicker), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	var tickerResponse TickerResponse
+	err := c.retry(ctx, true, func() error {
+		if err := c.requester.Wait(ctx, methodGetTicker); err != nil {
+			return err
+		}
 
-	// if instrument is omitted, ALL tickers are returned.
-	if instrument != "" {
-		q := req.URL.Query()
-		q.Add("instrument_name", instrument)
-		req.URL.RawQuery = q.Encode()
-	}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.requester.BaseURL, api.V2, methodGetTicker), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
 
-	res, err := c.requester.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to do request: %w", err)
-	}
-	defer res.Body.Close()
+		// if instrument is omitted, ALL tickers are returned.
+		if instrument != "" {
+			q := req.URL.Query()
+			q.Add("instrument_name", instrument)
+			req.URL.RawQuery = q.Encode()
+		}
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+		sent := c.clock.Now()
+		res, err := c.requester.Client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to do request: %w", err)
+		}
+		defer res.Body.Close()
 
-	var (
-		tickers []Ticker
-		code    json.Number
-	)
+		resBytes, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
 
-	var tickerResponse TickerResponse
-	if err := json.Unmarshal(resBytes, &tickerResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
-	}
+		tickerResponse = TickerResponse{}
+		if err := json.Unmarshal(resBytes, &tickerResponse); err != nil {
+			return fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(res.StatusCode, tickerResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
 
-	tickers = tickerResponse.Result.Data
-	code = tickerResponse.Code
+		c.observeServerTime(sent, c.clock.Now(), latestTickerTime(tickerResponse.Result.Data))
 
-	if err := c.requester.CheckErrorResponse(res.StatusCode, code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return tickers, nil
+	return tickerResponse.Result.Data, nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -233,11 +234,11 @@ func TestClient_GetTrades_Success(t *testing.T) {
 				{
 					Side:           cdcexchange.OrderSideSell,
 					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
+					Fee:            decimal.RequireFromString("0.014"),
 					TradeID:        "367107655537806900",
 					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
+					TradedPrice:    decimal.RequireFromString("7"),
+					TradedQuantity: decimal.RequireFromString("1"),
 					FeeCurrency:    "CRO",
 					OrderID:        "367107623521528450",
 				},
@@ -293,11 +294,11 @@ func TestClient_GetTrades_Success(t *testing.T) {
 				{
 					Side:           cdcexchange.OrderSideSell,
 					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
+					Fee:            decimal.RequireFromString("0.014"),
 					TradeID:        "367107655537806900",
 					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
+					TradedPrice:    decimal.RequireFromString("7"),
+					TradedQuantity: decimal.RequireFromString("1"),
 					FeeCurrency:    "CRO",
 					OrderID:        "367107623521528450",
 				},
@@ -364,11 +365,11 @@ func TestClient_GetTrades_Success(t *testing.T) {
 				{
 					Side:           cdcexchange.OrderSideSell,
 					InstrumentName: "ETH_CRO",
-					Fee:            0.014,
+					Fee:            decimal.RequireFromString("0.014"),
 					TradeID:        "367107655537806900",
 					CreateTime:     cdctime.Time(now),
-					TradedPrice:    7,
-					TradedQuantity: 1,
+					TradedPrice:    decimal.RequireFromString("7"),
+					TradedQuantity: decimal.RequireFromString("1"),
 					FeeCurrency:    "CRO",
 					OrderID:        "367107623521528450",
 				},
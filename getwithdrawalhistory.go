@@ -2,19 +2,34 @@ package cdcexchange
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
 )
 
 const (
 	methodGetWithdrawalHistory = "private/get-withdrawal-history"
+
+	WithdrawalStatusPending           WithdrawalStatus = "0"
+	WithdrawalStatusProcessing        WithdrawalStatus = "1"
+	WithdrawalStatusRejected          WithdrawalStatus = "2"
+	WithdrawalStatusPaymentInProgress WithdrawalStatus = "3"
+	WithdrawalStatusPaymentFailed     WithdrawalStatus = "4"
+	WithdrawalStatusCompleted         WithdrawalStatus = "5"
+	WithdrawalStatusCancelled         WithdrawalStatus = "6"
 )
 
 type (
+	// WithdrawalStatus is the current status of the withdrawal, represented by the Exchange's numeric code.
+	WithdrawalStatus string
+
 	// GetWithdrawalHistoryRequest is the request params sent for the private/get-withdrawal-history API.
 	//
 	// The maximum duration between Start and End is 24 hours.
@@ -40,8 +55,9 @@ type (
 		// Page represents the page number (for pagination)
 		// (0-based)
 		Page int `json:"page"`
-
-		Status string `json:"status"`
+		// Status represents the status of the withdrawals to return.
+		// if Status is omitted, withdrawals of all statuses will be returned.
+		Status WithdrawalStatus `json:"status"`
 	}
 
 	// GetWithdrawalHistoryResponse is the base response returned from the private/get-withdrawal-history API.
@@ -58,27 +74,93 @@ type (
 		WithdrawalList []Withdrawal `json:"withdrawal_list"`
 	}
 
+	// Withdrawal represents the details of a specific withdrawal.
 	Withdrawal struct {
-		Currency   string      `json:"currency"`
-		ClientWid  string      `json:"client_wid"`
-		Fee        float64     `json:"fee"`
-		CreateTime int64       `json:"create_time"`
-		Id         string      `json:"id"`
-		UpdateTime int64       `json:"update_time"`
-		Amount     float64     `json:"amount"`
-		Address    string      `json:"address"`
-		Status     string      `json:"status"`
-		Txid       string      `json:"txid"`
-		NetworkId  interface{} `json:"network_id"`
+		// Currency is the currency symbol of the withdrawal (e.g. BTC or ETH).
+		Currency string `json:"currency"`
+		// ClientWid is the optional client withdrawal ID (if provided in request when creating the withdrawal).
+		ClientWid string `json:"client_wid"`
+		// Fee is the withdrawal fee.
+		Fee decimal.Decimal `json:"fee"`
+		// CreateTime is the withdrawal creation time.
+		CreateTime cdctime.Time `json:"create_time"`
+		// Id is the unique identifier for the withdrawal.
+		Id string `json:"id"`
+		// UpdateTime is the withdrawal update time.
+		UpdateTime cdctime.Time `json:"update_time"`
+		// Amount is the withdrawal amount.
+		Amount decimal.Decimal `json:"amount"`
+		// Address is the address the withdrawal was sent to.
+		Address string `json:"address"`
+		// Status is the status of the withdrawal.
+		Status WithdrawalStatus `json:"status"`
+		// Txid is the transaction hash of the withdrawal on its network (once it has been sent).
+		Txid string `json:"txid"`
+		// NetworkId is the network the withdrawal was sent on (empty for the default network of the currency).
+		NetworkId NetworkID `json:"network_id"`
 	}
 )
 
-// GetWithdrawalHistory gets the withdrawal history for a particular instrument.
+// String returns the name of the status (e.g. COMPLETED), or its numeric code if it is not recognised.
+func (s WithdrawalStatus) String() string {
+	switch s {
+	case WithdrawalStatusPending:
+		return "PENDING"
+	case WithdrawalStatusProcessing:
+		return "PROCESSING"
+	case WithdrawalStatusRejected:
+		return "REJECTED"
+	case WithdrawalStatusPaymentInProgress:
+		return "PAYMENT_IN_PROGRESS"
+	case WithdrawalStatusPaymentFailed:
+		return "PAYMENT_FAILED"
+	case WithdrawalStatusCompleted:
+		return "COMPLETED"
+	case WithdrawalStatusCancelled:
+		return "CANCELLED"
+	default:
+		return string(s)
+	}
+}
+
+// IsTerminal returns whether the withdrawal can no longer change status.
+func (s WithdrawalStatus) IsTerminal() bool {
+	switch s {
+	case WithdrawalStatusRejected, WithdrawalStatusPaymentFailed, WithdrawalStatusCompleted, WithdrawalStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnmarshalJSON accepts the status code as either a string or a number.
+func (s *WithdrawalStatus) UnmarshalJSON(data []byte) error {
+	code, err := unmarshalStatusCode(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal withdrawal status: %w", err)
+	}
+
+	*s = WithdrawalStatus(code)
+	return nil
+}
+
+// unmarshalStatusCode unmarshals a numeric status code which may be sent as a string or a number.
+func unmarshalStatusCode(data []byte) (string, error) {
+	var code json.Number
+	if err := json.Unmarshal(data, &code); err != nil {
+		return "", err
+	}
+
+	return code.String(), nil
+}
+
+// GetWithdrawalHistory gets the withdrawal history for a particular currency.
 //
 // Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
 // If paging is used, enumerate each page (starting with 0) until an empty withdrawal_list array appears in the response.
+// IterateWithdrawalHistory can be used to do this automatically.
 //
-// req.Timeframe can be left blank to get withdrawals for all instruments.
+// req.Currency can be left blank to get withdrawals for all currencies.
 //
 // Method: private/get-withdrawal-history
 func (c *Client) GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHistoryRequest) ([]Withdrawal, error) {
@@ -88,12 +170,11 @@ func (c *Client) GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHist
 	if req.PageSize > 200 {
 		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be greater than 200"}
 	}
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+	}
 
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	params := make(map[string]interface{})
 
 	if req.Currency != "" {
 		params["currency"] = req.Currency
@@ -109,38 +190,51 @@ func (c *Client) GetWithdrawalHistory(ctx context.Context, req GetWithdrawalHist
 	}
 	params["page"] = req.Page
 	if req.Status != "" {
-		params["status"] = req.Status
-	}
-
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodGetWithdrawalHistory,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
-
-	body := api.Request{
-		ID:        id,
-		Method:    methodGetWithdrawalHistory,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
+		params["status"] = string(req.Status)
 	}
 
 	var getWithdrawalHistoryResponse GetWithdrawalHistoryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodGetWithdrawalHistory, &getWithdrawalHistoryResponse)
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetWithdrawalHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetWithdrawalHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getWithdrawalHistoryResponse = GetWithdrawalHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetWithdrawalHistory, &getWithdrawalHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getWithdrawalHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
-
-	if err := c.requester.CheckErrorResponse(statusCode, getWithdrawalHistoryResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		return nil, err
 	}
 
 	return getWithdrawalHistoryResponse.Result.WithdrawalList, nil
@@ -4,7 +4,9 @@ go 1.17
 
 require (
 	github.com/golang/mock v1.6.0
-	github.com/jonboulle/clockwork v0.2.2
+	github.com/gorilla/websocket v1.5.0
+	github.com/jonboulle/clockwork v0.4.0
+	github.com/shopspring/decimal v1.3.1
 	github.com/stretchr/testify v1.5.1
 )
 
@@ -0,0 +1,148 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// historyWindow is the maximum duration between the start & end of a private/get-trades or private/get-order-history query.
+const historyWindow = 24 * time.Hour
+
+// historyWindows splits start to end into consecutive windows of at most historyWindow.
+func historyWindows(start, end time.Time) [][2]time.Time {
+	var windows [][2]time.Time
+	for from := start; from.Before(end); from = from.Add(historyWindow) {
+		to := from.Add(historyWindow)
+		if to.After(end) {
+			to = end
+		}
+		windows = append(windows, [2]time.Time{from, to})
+	}
+	return windows
+}
+
+// validateHistoryRange validates the range of an IterateTradesInRange or IterateOrderHistoryInRange request,
+// defaulting end to now.
+func validateHistoryRange(start, end, now time.Time) (time.Time, error) {
+	if start.IsZero() {
+		return time.Time{}, errors.InvalidParameterError{Parameter: "req.Start", Reason: "cannot be empty"}
+	}
+	if end.IsZero() {
+		end = now
+	}
+	if !end.After(start) {
+		return time.Time{}, errors.InvalidParameterError{Parameter: "req.End", Reason: "must be after req.Start"}
+	}
+	return end, nil
+}
+
+// IterateTradesInRange returns an iterator over the executed trades between req.Start and req.End
+// (Default: now), which may be further apart than the 24 hours allowed by private/get-trades.
+//
+// The range is split into 24 hour windows, each of which is paged through in turn (req.Page is ignored).
+// Trades are returned in creation time order, & trades at the boundary of two windows are only returned once.
+//
+// Method: private/get-trades
+func (c *Client) IterateTradesInRange(req GetTradesRequest) *TradeIterator {
+	it := &TradeIterator{}
+	it.unit = "window"
+
+	end, err := validateHistoryRange(req.Start, req.End, c.clock.Now())
+	if err != nil {
+		it.err = err
+		return it
+	}
+
+	var (
+		windows = historyWindows(req.Start, end)
+		// boundary is the IDs of the trades returned at the end of the previous window, as they are also returned from the next.
+		boundary = make(map[string]struct{})
+	)
+	it.fetch = func(ctx context.Context, window int) (int, bool, error) {
+		windowReq := req
+		windowReq.Start, windowReq.End, windowReq.Page = windows[window][0], windows[window][1], 0
+
+		trades, err := c.IterateTrades(windowReq).All(ctx, 0)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get trades from %s to %s: %w", windowReq.Start, windowReq.End, err)
+		}
+
+		it.trades = it.trades[:0]
+		next := make(map[string]struct{})
+		for _, t := range trades {
+			if !t.CreateTime.Time().Before(windowReq.End) {
+				next[t.TradeID] = struct{}{}
+			}
+			if _, ok := boundary[t.TradeID]; ok {
+				continue
+			}
+			it.trades = append(it.trades, t)
+		}
+		boundary = next
+
+		sort.SliceStable(it.trades, func(i, j int) bool {
+			return it.trades[i].CreateTime.Time().Before(it.trades[j].CreateTime.Time())
+		})
+
+		return len(it.trades), window == len(windows)-1, nil
+	}
+
+	return it
+}
+
+// IterateOrderHistoryInRange returns an iterator over the order history between req.Start and req.End
+// (Default: now), which may be further apart than the 24 hours allowed by private/get-order-history.
+//
+// The range is split into 24 hour windows, each of which is paged through in turn (req.Page is ignored).
+// Orders are returned in creation time order, & orders returned from consecutive windows are only returned once.
+//
+// Method: private/get-order-history
+func (c *Client) IterateOrderHistoryInRange(req GetOrderHistoryRequest) *OrderHistoryIterator {
+	it := &OrderHistoryIterator{}
+	it.unit = "window"
+
+	end, err := validateHistoryRange(req.Start, req.End, c.clock.Now())
+	if err != nil {
+		it.err = err
+		return it
+	}
+
+	var (
+		windows = historyWindows(req.Start, end)
+		// previous is the IDs of the orders returned from the previous window, as orders at the end of a window
+		// (or updated since it was fetched) are also returned from the next.
+		previous = make(map[string]struct{})
+	)
+	it.fetch = func(ctx context.Context, window int) (int, bool, error) {
+		windowReq := req
+		windowReq.Start, windowReq.End, windowReq.Page = windows[window][0], windows[window][1], 0
+
+		orders, err := c.IterateOrderHistory(windowReq).All(ctx, 0)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get orders from %s to %s: %w", windowReq.Start, windowReq.End, err)
+		}
+
+		it.orders = it.orders[:0]
+		next := make(map[string]struct{}, len(orders))
+		for _, o := range orders {
+			next[o.OrderID] = struct{}{}
+			if _, ok := previous[o.OrderID]; ok {
+				continue
+			}
+			it.orders = append(it.orders, o)
+		}
+		previous = next
+
+		sort.SliceStable(it.orders, func(i, j int) bool {
+			return it.orders[i].CreateTime.Time().Before(it.orders[j].CreateTime.Time())
+		})
+
+		return len(it.orders), window == len(windows)-1, nil
+	}
+
+	return it
+}
@@ -0,0 +1,175 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// windowServer serves windows[start_ts][page] as the list of the method's result,
+// or an empty list once the pages of the window are exhausted.
+// It returns a Client which sends requests to the server, and a func returning the params of each request.
+func windowServer(t *testing.T, method string, list string, windows map[int64][]string) (*cdcexchange.Client, func() []map[string]interface{}) {
+	s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		assert.Equal(t, method, req.Method)
+
+		var (
+			pages = windows[int64(req.Params["start_ts"].(float64))]
+			page  = int(req.Params["page"].(float64))
+			items string
+		)
+		if page < len(pages) {
+			items = pages[page]
+		}
+
+		return okResponse(fmt.Sprintf(`{"%s":[%s]}`, list, items))
+	})
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRateLimit(method, cdcexchange.RateLimit{}),
+	)
+	require.NoError(t, err)
+
+	return client, func() []map[string]interface{} {
+		var params []map[string]interface{}
+		for _, req := range requests() {
+			params = append(params, req.Params)
+		}
+		return params
+	}
+}
+
+func TestClient_IterateTradesInRange_Error(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		req         cdcexchange.GetTradesRequest
+		expectedErr error
+	}{
+		{
+			name:        "returns error when start is empty",
+			req:         cdcexchange.GetTradesRequest{End: now},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Start", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when end is not after start",
+			req:         cdcexchange.GetTradesRequest{Start: now, End: now},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.End", Reason: "must be after req.Start"},
+		},
+		{
+			name:        "returns error when start is in the future",
+			req:         cdcexchange.GetTradesRequest{Start: now.Add(time.Hour)},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.End", Reason: "must be after req.Start"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := windowServer(t, cdcexchange.MethodGetTrades, "trade_list", nil)
+
+			trades, err := client.IterateTradesInRange(tt.req).All(context.Background(), 0)
+			require.Error(t, err)
+
+			assert.Nil(t, trades)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Empty(t, requests())
+		})
+	}
+}
+
+func TestClient_IterateTradesInRange(t *testing.T) {
+	var (
+		start = time.Now().Add(-50 * time.Hour).Round(time.Millisecond)
+		end   = start.Add(50 * time.Hour)
+	)
+	trade := func(id string, createTime time.Time) string {
+		return fmt.Sprintf(`{"trade_id":"%s","create_time":%d}`, id, createTime.UnixMilli())
+	}
+
+	client, requests := windowServer(t, cdcexchange.MethodGetTrades, "trade_list", map[int64][]string{
+		start.UnixMilli(): {
+			// newest first.
+			trade("boundary", start.Add(24*time.Hour)) + "," + trade("2", start.Add(2*time.Hour)),
+			trade("1", start.Add(time.Hour)),
+		},
+		// the boundary trade is returned from both windows.
+		start.Add(24 * time.Hour).UnixMilli(): {
+			trade("boundary", start.Add(24*time.Hour)),
+		},
+		start.Add(48 * time.Hour).UnixMilli(): {
+			trade("4", start.Add(49*time.Hour)) + "," + trade("3", start.Add(48*time.Hour)),
+		},
+	})
+
+	it := client.IterateTradesInRange(cdcexchange.GetTradesRequest{
+		InstrumentName: "BTC_USDT",
+		Start:          start,
+		End:            end,
+		PageSize:       2,
+		Page:           5,
+	})
+
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Trade().TradeID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"1", "2", "boundary", "3", "4"}, ids)
+
+	var windows []string
+	for _, params := range requests() {
+		assert.Equal(t, "BTC_USDT", params["instrument_name"])
+		windows = append(windows, fmt.Sprintf("%v-%v/%v",
+			time.Duration(int64(params["start_ts"].(float64))-start.UnixMilli())*time.Millisecond,
+			time.Duration(int64(params["end_ts"].(float64))-start.UnixMilli())*time.Millisecond,
+			params["page"],
+		))
+	}
+	assert.Equal(t, strings.Join([]string{
+		"0s-24h0m0s/0", "0s-24h0m0s/1", "0s-24h0m0s/2",
+		"24h0m0s-48h0m0s/0", "24h0m0s-48h0m0s/1",
+		"48h0m0s-50h0m0s/0", "48h0m0s-50h0m0s/1",
+	}, ","), strings.Join(windows, ","))
+}
+
+func TestClient_IterateOrderHistoryInRange(t *testing.T) {
+	var (
+		start = time.Now().Add(-30 * time.Hour).Round(time.Millisecond)
+		end   = start.Add(30 * time.Hour)
+	)
+	order := func(id string, createTime time.Time) string {
+		return fmt.Sprintf(`{"order_id":"%s","create_time":%d}`, id, createTime.UnixMilli())
+	}
+
+	client, requests := windowServer(t, cdcexchange.MethodGetOrderHistory, "order_list", map[int64][]string{
+		start.UnixMilli(): {
+			order("2", start.Add(2*time.Hour)) + "," + order("1", start.Add(time.Hour)),
+		},
+		// an order updated in the later window is returned again.
+		start.Add(24 * time.Hour).UnixMilli(): {
+			order("3", start.Add(25*time.Hour)) + "," + order("2", start.Add(2*time.Hour)),
+		},
+	})
+
+	orders, err := client.IterateOrderHistoryInRange(cdcexchange.GetOrderHistoryRequest{Start: start, End: end}).All(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 3)
+	assert.Equal(t, "1", orders[0].OrderID)
+	assert.Equal(t, "2", orders[1].OrderID)
+	assert.Equal(t, "3", orders[2].OrderID)
+	assert.Len(t, requests(), 4)
+}
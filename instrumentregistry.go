@@ -0,0 +1,338 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	defaultInstrumentRefreshInterval = time.Hour
+	// instrumentFetchTimeout is the time allowed for a (shared) fetch of the instruments.
+	instrumentFetchTimeout = 30 * time.Second
+	// instrumentMinBackoff & instrumentMaxBackoff bound the delay before a failed fetch is retried,
+	// doubling after each consecutive failure.
+	instrumentMinBackoff = time.Second
+	instrumentMaxBackoff = time.Minute
+
+	// RoundDown rounds towards the lower tick (floor).
+	RoundDown RoundingMode = "DOWN"
+	// RoundUp rounds towards the higher tick (ceil).
+	RoundUp RoundingMode = "UP"
+	// RoundNearest rounds to the closest tick, halfway values are rounded up.
+	RoundNearest RoundingMode = "NEAREST"
+)
+
+type (
+	// RoundingMode determines which tick a price or quantity is snapped to, unknown modes round down.
+	RoundingMode string
+
+	// InstrumentRegistry is a cache of the instruments returned from public/get-instruments.
+	//
+	// Instruments are fetched on first use and re-fetched by the first lookup made once the refresh interval has
+	// elapsed (see WithInstrumentRefreshInterval), or in the background on the refresh interval once Start is called.
+	// If a fetch fails, the previously fetched instruments continue to be used, and the fetch is not retried
+	// until a backoff of 1s (doubling up to 1m) has elapsed, lookups returning the error in the meantime if no
+	// instruments have been fetched yet.
+	//
+	// Concurrent lookups share a single fetch, which is made without blocking lookups that do not need it.
+	// The fetch is not cancelled by the ctx of the lookups waiting for it, and times out after 30s.
+	//
+	// All methods are safe for concurrent use.
+	InstrumentRegistry struct {
+		client          *Client
+		refreshInterval time.Duration
+
+		mu          sync.Mutex
+		instruments []Instrument
+		byName      map[string]Instrument
+		refreshedAt time.Time
+		// fetch is the fetch in progress, it is nil when instruments are not being fetched.
+		fetch *instrumentFetch
+		// failures is the number of consecutive failed fetches, the last failing with err.
+		// fetches are not retried by lookups until retryAt.
+		failures int
+		err      error
+		retryAt  time.Time
+	}
+
+	// instrumentFetch is a public/get-instruments call shared by concurrent lookups.
+	instrumentFetch struct {
+		// done is closed once the fetch completes, err is set before.
+		done chan struct{}
+		err  error
+	}
+)
+
+// WithInstrumentRefreshInterval will initialise the Client to re-fetch instruments on the first lookup made once
+// interval has elapsed since they were last fetched, or every interval once InstrumentRegistry.Start is called.
+// Default: 1 hour.
+//
+// interval can be set to 0 to never re-fetch instruments after they are first loaded.
+func WithInstrumentRefreshInterval(interval time.Duration) ClientOption {
+	return func(c *Client) error {
+		if interval < 0 {
+			return errors.InvalidParameterError{Parameter: "interval", Reason: "cannot be less than 0"}
+		}
+
+		c.instruments.refreshInterval = interval
+		return nil
+	}
+}
+
+// Instruments returns the Client's instrument registry.
+func (c *Client) Instruments() *InstrumentRegistry {
+	return c.instruments
+}
+
+func newInstrumentRegistry(client *Client) *InstrumentRegistry {
+	return &InstrumentRegistry{
+		client:          client,
+		refreshInterval: defaultInstrumentRefreshInterval,
+	}
+}
+
+// Instrument returns the instrument with the given name (e.g. BTC_USDT).
+//
+// errors.ErrSymbolNotFound is returned if the instrument does not exist.
+func (r *InstrumentRegistry) Instrument(ctx context.Context, name string) (Instrument, error) {
+	_, byName, err := r.load(ctx)
+	if err != nil {
+		return Instrument{}, err
+	}
+
+	instrument, ok := byName[name]
+	if !ok {
+		return Instrument{}, errors.ValidationError{
+			Parameter: "name",
+			Reason:    fmt.Sprintf("%s is not a known instrument", name),
+			Err:       errors.ErrSymbolNotFound,
+		}
+	}
+
+	return instrument, nil
+}
+
+// ByCurrency returns the instruments with the given base & quote currencies (e.g. BTC & USDT).
+//
+// base or quote can be left blank to match any currency.
+func (r *InstrumentRegistry) ByCurrency(ctx context.Context, base string, quote string) ([]Instrument, error) {
+	all, _, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var instruments []Instrument
+	for _, instrument := range all {
+		if (base == "" || instrument.BaseCurrency == base) && (quote == "" || instrument.QuoteCurrency == quote) {
+			instruments = append(instruments, instrument)
+		}
+	}
+
+	return instruments, nil
+}
+
+// RoundPrice snaps price to the named instrument's price tick size (see Instrument.RoundPrice).
+func (r *InstrumentRegistry) RoundPrice(ctx context.Context, name string, price decimal.Decimal, mode RoundingMode) (decimal.Decimal, error) {
+	instrument, err := r.Instrument(ctx, name)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	return instrument.RoundPrice(price, mode), nil
+}
+
+// RoundQuantity snaps quantity to the named instrument's quantity tick size (see Instrument.RoundQuantity).
+func (r *InstrumentRegistry) RoundQuantity(ctx context.Context, name string, quantity decimal.Decimal, mode RoundingMode) (decimal.Decimal, error) {
+	instrument, err := r.Instrument(ctx, name)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	return instrument.RoundQuantity(quantity, mode), nil
+}
+
+// Refresh re-fetches the instruments regardless of when they were last fetched.
+func (r *InstrumentRegistry) Refresh(ctx context.Context) error {
+	return r.refresh(ctx)
+}
+
+// Start refreshes the instruments in the background every refresh interval (on the Client's clock) until ctx is done,
+// so that lookups are not held up by a refresh. Failed refreshes are retried after the same backoff as lookups.
+//
+// Start should only be called once.
+func (r *InstrumentRegistry) Start(ctx context.Context) {
+	go func() {
+		for {
+			wait, ok := r.untilRefresh()
+			if !ok {
+				// instruments are never re-fetched once loaded.
+				return
+			}
+
+			if wait > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-r.client.clock.After(wait):
+				}
+			}
+
+			// a failure is kept to be backed off from (& returned from lookups while there are no instruments).
+			_ = r.refresh(ctx)
+		}
+	}()
+}
+
+// untilRefresh returns the time until the instruments are next due to be fetched, false if they never are.
+func (r *InstrumentRegistry) untilRefresh() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.client.clock.Now()
+	switch {
+	case r.failures > 0:
+		return r.retryAt.Sub(now), true
+	case r.byName == nil:
+		return 0, true
+	case r.refreshInterval == 0:
+		return 0, false
+	default:
+		return r.refreshedAt.Add(r.refreshInterval).Sub(now), true
+	}
+}
+
+// load returns the instruments, fetching them if they have not been fetched yet or the refresh interval has elapsed.
+//
+// The returned slice & map are never modified (a refresh replaces them), so they can be read without the lock.
+func (r *InstrumentRegistry) load(ctx context.Context) ([]Instrument, map[string]Instrument, error) {
+	r.mu.Lock()
+	loaded := r.byName != nil
+	stale := !loaded || (r.refreshInterval > 0 && r.client.clock.Since(r.refreshedAt) >= r.refreshInterval)
+	backoff := r.failures > 0 && r.client.clock.Now().Before(r.retryAt)
+	lastErr := r.err
+	r.mu.Unlock()
+
+	switch {
+	case !stale:
+	case backoff && !loaded:
+		return nil, nil, lastErr
+	case backoff:
+		// stale instruments are still usable until the failed refresh is retried.
+	default:
+		if err := r.refresh(ctx); err != nil && !loaded {
+			return nil, nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.instruments, r.byName, nil
+}
+
+// refresh fetches the instruments, joining the fetch in progress if there is one, and waits for the fetch to complete
+// or ctx to be done.
+func (r *InstrumentRegistry) refresh(ctx context.Context) error {
+	r.mu.Lock()
+	fetch := r.fetch
+	if fetch == nil {
+		fetch = &instrumentFetch{done: make(chan struct{})}
+		r.fetch = fetch
+		go r.fetchInstruments(fetch)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchInstruments makes the shared fetch, which is detached from the ctx of the lookups waiting for it
+// (so that one of them being cancelled does not fail the others).
+func (r *InstrumentRegistry) fetchInstruments(fetch *instrumentFetch) {
+	defer close(fetch.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), instrumentFetchTimeout)
+	defer cancel()
+
+	instruments, err := r.client.GetInstruments(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fetch = nil
+
+	if err != nil {
+		fetch.err = fmt.Errorf("failed to get instruments: %w", err)
+
+		backoff := instrumentMaxBackoff
+		if r.failures < 32 {
+			if exp := instrumentMinBackoff << uint(r.failures); exp > 0 && exp < instrumentMaxBackoff {
+				backoff = exp
+			}
+		}
+		r.failures++
+		r.err = fetch.err
+		r.retryAt = r.client.clock.Now().Add(backoff)
+		return
+	}
+
+	byName := make(map[string]Instrument, len(instruments))
+	for _, instrument := range instruments {
+		byName[instrument.InstrumentName] = instrument
+	}
+
+	r.instruments = instruments
+	r.byName = byName
+	r.refreshedAt = r.client.clock.Now()
+	r.failures = 0
+	r.err = nil
+}
+
+// RoundPrice snaps price to a multiple of PriceTickSize.
+//
+// If the instrument has no PriceTickSize, price is rounded to PriceDecimals decimal places instead.
+func (i Instrument) RoundPrice(price decimal.Decimal, mode RoundingMode) decimal.Decimal {
+	return roundToTick(price, i.PriceTickSize, i.PriceDecimals, mode)
+}
+
+// RoundQuantity snaps quantity to a multiple of QuantityTickSize.
+//
+// If the instrument has no QuantityTickSize, quantity is rounded to QuantityDecimals decimal places instead.
+func (i Instrument) RoundQuantity(quantity decimal.Decimal, mode RoundingMode) decimal.Decimal {
+	return roundToTick(quantity, i.QuantityTickSize, i.QuantityDecimals, mode)
+}
+
+func roundToTick(d decimal.Decimal, tick decimal.Decimal, decimals int, mode RoundingMode) decimal.Decimal {
+	if !tick.IsPositive() {
+		// a tick of 10^-decimals gives the same result as rounding to decimals places.
+		tick = decimal.New(1, int32(-decimals))
+	}
+
+	// d = ticks*tick + remainder, where ticks is truncated towards zero.
+	ticks, remainder := d.QuoRem(tick, 0)
+	if remainder.IsNegative() {
+		// floor the number of ticks, so that remainder is always in [0, tick).
+		ticks = ticks.Sub(decimal.New(1, 0))
+		remainder = remainder.Add(tick)
+	}
+
+	switch {
+	case remainder.IsZero():
+	case mode == RoundUp:
+		ticks = ticks.Add(decimal.New(1, 0))
+	case mode == RoundNearest && remainder.Mul(decimal.New(2, 0)).GreaterThanOrEqual(tick):
+		ticks = ticks.Add(decimal.New(1, 0))
+	}
+
+	return ticks.Mul(tick)
+}
@@ -0,0 +1,429 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+)
+
+func instrumentNames(instruments []cdcexchange.Instrument) []string {
+	names := make([]string, 0, len(instruments))
+	for _, instrument := range instruments {
+		names = append(names, instrument.InstrumentName)
+	}
+	return names
+}
+
+func TestWithInstrumentRefreshInterval_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithInstrumentRefreshInterval(-1))
+	require.Error(t, err)
+
+	assert.Empty(t, client)
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "interval", Reason: "cannot be less than 0"}, err)
+}
+
+func TestInstrumentRegistry(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	now := time.Now()
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClockAt(now)
+		calls       int32
+		failing     int32
+
+		btcUSDT = cdcexchange.Instrument{InstrumentName: "BTC_USDT", BaseCurrency: "BTC", QuoteCurrency: "USDT", PriceTickSize: decimal.RequireFromString("0.01")}
+		ethUSDT = cdcexchange.Instrument{InstrumentName: "ETH_USDT", BaseCurrency: "ETH", QuoteCurrency: "USDT", PriceTickSize: decimal.RequireFromString("0.01")}
+		ethBTC  = cdcexchange.Instrument{InstrumentName: "ETH_BTC", BaseCurrency: "ETH", QuoteCurrency: "BTC", PriceTickSize: decimal.RequireFromString("0.000001")}
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetInstruments)
+
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			require.NoError(t, json.NewEncoder(w).Encode(api.BaseResponse{Code: "10001"}))
+			return
+		}
+
+		instruments := []cdcexchange.Instrument{btcUSDT, ethUSDT}
+		if atomic.AddInt32(&calls, 1) > 1 {
+			instruments = append(instruments, ethBTC)
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.InstrumentsResponse{
+			Result: cdcexchange.InstrumentResult{Instruments: instruments},
+		}))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithInstrumentRefreshInterval(time.Minute),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	registry := client.Instruments()
+
+	instrument, err := registry.Instrument(ctx, "BTC_USDT")
+	require.NoError(t, err)
+	assert.Equal(t, btcUSDT.InstrumentName, instrument.InstrumentName)
+
+	_, err = registry.Instrument(ctx, "ETH_BTC")
+	assert.True(t, errors.Is(err, cdcerrors.ErrSymbolNotFound))
+
+	instruments, err := registry.ByCurrency(ctx, "", "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC_USDT", "ETH_USDT"}, instrumentNames(instruments))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	price, err := registry.RoundPrice(ctx, "BTC_USDT", decimal.RequireFromString("100.005"), cdcexchange.RoundNearest)
+	require.NoError(t, err)
+	assert.Equal(t, "100.01", price.String())
+
+	// stale instruments are still returned if the refresh fails.
+	atomic.StoreInt32(&failing, 1)
+	clock.Advance(time.Minute)
+
+	instruments, err = registry.ByCurrency(ctx, "ETH", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETH_USDT"}, instrumentNames(instruments))
+
+	atomic.StoreInt32(&failing, 0)
+
+	// the failed refresh is not retried until its backoff has elapsed.
+	instruments, err = registry.ByCurrency(ctx, "ETH", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETH_USDT"}, instrumentNames(instruments))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	clock.Advance(time.Second)
+
+	instruments, err = registry.ByCurrency(ctx, "ETH", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETH_USDT", "ETH_BTC"}, instrumentNames(instruments))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	instrument, err = registry.Instrument(ctx, "ETH_BTC")
+	require.NoError(t, err)
+	assert.Equal(t, ethBTC.InstrumentName, instrument.InstrumentName)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	require.NoError(t, registry.Refresh(ctx))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestInstrumentRegistry_RefreshDoesNotBlockLookups(t *testing.T) {
+	const id = int64(1234)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		calls       int32
+		fetching    = make(chan struct{})
+		release     = make(chan struct{})
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			// the refresh is held until released.
+			close(fetching)
+			<-release
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.InstrumentsResponse{
+			Result: cdcexchange.InstrumentResult{Instruments: []cdcexchange.Instrument{{InstrumentName: "BTC_USDT"}}},
+		}))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	registry := client.Instruments()
+
+	_, err = registry.Instrument(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	refreshed := make(chan error, 1)
+	go func() { refreshed <- registry.Refresh(ctx) }()
+
+	select {
+	case <-fetching:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for refresh")
+	}
+
+	// lookups are not blocked by the refresh in progress.
+	lookup := make(chan error, 1)
+	go func() {
+		_, err := registry.Instrument(ctx, "BTC_USDT")
+		lookup <- err
+	}()
+
+	select {
+	case err := <-lookup:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for lookup, blocked by refresh")
+	}
+
+	close(release)
+
+	select {
+	case err := <-refreshed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for refresh to complete")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestInstrument_Round(t *testing.T) {
+	instrument := cdcexchange.Instrument{
+		PriceTickSize:    decimal.RequireFromString("0.05"),
+		QuantityDecimals: 3,
+	}
+
+	tests := []struct {
+		name             string
+		value            string
+		mode             cdcexchange.RoundingMode
+		expectedPrice    string
+		expectedQuantity string
+	}{
+		{
+			name:             "rounds down",
+			value:            "1.2345",
+			mode:             cdcexchange.RoundDown,
+			expectedPrice:    "1.2",
+			expectedQuantity: "1.234",
+		},
+		{
+			name:             "rounds up",
+			value:            "1.2345",
+			mode:             cdcexchange.RoundUp,
+			expectedPrice:    "1.25",
+			expectedQuantity: "1.235",
+		},
+		{
+			name:             "rounds to nearest",
+			value:            "1.2345",
+			mode:             cdcexchange.RoundNearest,
+			expectedPrice:    "1.25",
+			expectedQuantity: "1.235",
+		},
+		{
+			name:             "rounds to nearest below halfway",
+			value:            "1.2249",
+			mode:             cdcexchange.RoundNearest,
+			expectedPrice:    "1.2",
+			expectedQuantity: "1.225",
+		},
+		{
+			name:             "does not round values already on a tick",
+			value:            "1.15",
+			mode:             cdcexchange.RoundUp,
+			expectedPrice:    "1.15",
+			expectedQuantity: "1.15",
+		},
+		{
+			name:             "rounds negative values down",
+			value:            "-1.21",
+			mode:             cdcexchange.RoundDown,
+			expectedPrice:    "-1.25",
+			expectedQuantity: "-1.21",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := decimal.RequireFromString(tt.value)
+
+			assert.Equal(t, tt.expectedPrice, instrument.RoundPrice(value, tt.mode).String())
+			assert.Equal(t, tt.expectedQuantity, instrument.RoundQuantity(value, tt.mode).String())
+		})
+	}
+}
+
+func TestInstrumentRegistry_Fetch(t *testing.T) {
+	const id = int64(1234)
+
+	// newRegistry creates a registry whose instruments are served by respond.
+	newRegistry := func(t *testing.T, clock clockwork.Clock, respond func(w http.ResponseWriter, r *http.Request)) *cdcexchange.InstrumentRegistry {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		idGenerator := id_mocks.NewMockIDGenerator(ctrl)
+		idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+		s := httptest.NewServer(http.HandlerFunc(respond))
+		t.Cleanup(s.Close)
+
+		client, err := cdcexchange.New("some api key", "some secret key",
+			cdcexchange.WithIDGenerator(idGenerator),
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithInstrumentRefreshInterval(time.Minute),
+		)
+		require.NoError(t, err)
+
+		return client.Instruments()
+	}
+	instruments := func(w http.ResponseWriter) {
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.InstrumentsResponse{
+			Result: cdcexchange.InstrumentResult{Instruments: []cdcexchange.Instrument{{InstrumentName: "BTC_USDT"}}},
+		}))
+	}
+
+	t.Run("is not failed by a cancelled lookup waiting for it", func(t *testing.T) {
+		var (
+			calls    int32
+			fetching = make(chan struct{})
+			release  = make(chan struct{})
+		)
+		registry := newRegistry(t, clockwork.NewFakeClock(), func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(fetching)
+				select {
+				case <-release:
+				case <-r.Context().Done():
+					return
+				}
+			}
+			instruments(w)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled := make(chan error, 1)
+		go func() {
+			_, err := registry.Instrument(ctx, "BTC_USDT")
+			cancelled <- err
+		}()
+
+		select {
+		case <-fetching:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for fetch")
+		}
+		cancel()
+
+		select {
+		case err := <-cancelled:
+			assert.True(t, errors.Is(err, context.Canceled))
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for cancelled lookup")
+		}
+
+		// the lookup joins the fetch, which is still in progress.
+		lookup := make(chan error, 1)
+		go func() {
+			_, err := registry.Instrument(context.Background(), "BTC_USDT")
+			lookup <- err
+		}()
+		close(release)
+
+		select {
+		case err := <-lookup:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for lookup")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("backs off after a failure", func(t *testing.T) {
+		var (
+			clock = clockwork.NewFakeClock()
+			calls int32
+		)
+		registry := newRegistry(t, clock, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				require.NoError(t, json.NewEncoder(w).Encode(api.BaseResponse{Code: "10001"}))
+				return
+			}
+			instruments(w)
+		})
+
+		for _, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+			_, err := registry.Instrument(context.Background(), "BTC_USDT")
+			require.True(t, errors.Is(err, cdcerrors.ErrSystemError))
+			calls := atomic.LoadInt32(&calls)
+
+			// lookups return the error without fetching until the backoff has elapsed.
+			_, err = registry.Instrument(context.Background(), "BTC_USDT")
+			require.True(t, errors.Is(err, cdcerrors.ErrSystemError))
+			assert.Equal(t, calls, atomic.LoadInt32(&calls))
+
+			clock.Advance(backoff)
+		}
+
+		_, err := registry.Instrument(context.Background(), "BTC_USDT")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("refreshes in the background on the interval once started", func(t *testing.T) {
+		var (
+			clock = clockwork.NewFakeClock()
+			calls int32
+		)
+		registry := newRegistry(t, clock, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			instruments(w)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		registry.Start(ctx)
+
+		clock.BlockUntil(1)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		clock.Advance(time.Minute)
+		clock.BlockUntil(1)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+		_, err := registry.Instrument(ctx, "BTC_USDT")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+var (
+	// DefaultRateLimits are the Exchange's published per-method rate limits.
+	DefaultRateLimits = map[string]RateLimit{
+		"private/create-order":      {Requests: 15, Interval: 100 * time.Millisecond},
+		"private/cancel-order":      {Requests: 15, Interval: 100 * time.Millisecond},
+		"private/cancel-all-orders": {Requests: 15, Interval: 100 * time.Millisecond},
+		"private/get-order-detail":  {Requests: 30, Interval: 100 * time.Millisecond},
+		"private/get-trades":        {Requests: 1, Interval: time.Second},
+		"private/get-order-history": {Requests: 1, Interval: time.Second},
+
+		"private/margin/create-order":      {Requests: 15, Interval: 100 * time.Millisecond},
+		"private/margin/cancel-order":      {Requests: 15, Interval: 100 * time.Millisecond},
+		"private/margin/get-order-history": {Requests: 1, Interval: time.Second},
+	}
+
+	// DefaultPrivateRateLimit is the rate limit of private methods not in DefaultRateLimits.
+	DefaultPrivateRateLimit = RateLimit{Requests: 3, Interval: 100 * time.Millisecond}
+	// DefaultPublicRateLimit is the rate limit of public methods not in DefaultRateLimits.
+	DefaultPublicRateLimit = RateLimit{Requests: 100, Interval: time.Second}
+)
+
+type (
+	// RateLimit allows a burst of Requests, refilled evenly over Interval.
+	// A RateLimit with 0 Requests is not limited.
+	RateLimit struct {
+		Requests int
+		Interval time.Duration
+	}
+
+	// RateLimiter is a token bucket rate limiter with a bucket per method.
+	RateLimiter struct {
+		Clock clockwork.Clock
+
+		mu      sync.Mutex
+		limits  map[string]RateLimit
+		buckets map[string]*bucket
+	}
+
+	bucket struct {
+		limit    RateLimit
+		tokens   float64
+		refilled time.Time
+	}
+)
+
+// NewRateLimiter creates a RateLimiter using the DefaultRateLimits.
+func NewRateLimiter(clock clockwork.Clock) *RateLimiter {
+	limits := make(map[string]RateLimit, len(DefaultRateLimits))
+	for method, limit := range DefaultRateLimits {
+		limits[method] = limit
+	}
+
+	return &RateLimiter{
+		Clock:   clock,
+		limits:  limits,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// SetLimit sets the rate limit of method, replacing its default.
+func (l *RateLimiter) SetLimit(method string, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits[method] = limit
+}
+
+// Wait blocks until a request can be made for method, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	for {
+		wait, ok := l.reserve(method)
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.Clock.After(wait):
+		}
+	}
+}
+
+// reserve takes a token for method, if no token is available the time until the next token is returned.
+func (l *RateLimiter) reserve(method string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.limit(method)
+	if limit.Requests <= 0 || limit.Interval <= 0 {
+		return 0, true
+	}
+
+	now := l.Clock.Now()
+
+	b, ok := l.buckets[method]
+	if !ok || b.limit != limit {
+		// the bucket is (re)created full, so changing a limit does not block callers.
+		b = &bucket{limit: limit, tokens: float64(limit.Requests), refilled: now}
+		l.buckets[method] = b
+	}
+
+	perToken := limit.Interval / time.Duration(limit.Requests)
+
+	b.tokens += float64(now.Sub(b.refilled)) / float64(perToken)
+	if b.tokens > float64(limit.Requests) {
+		b.tokens = float64(limit.Requests)
+	}
+	b.refilled = now
+
+	if b.tokens < 1 {
+		return time.Duration(math.Ceil((1 - b.tokens) * float64(perToken))), false
+	}
+
+	b.tokens--
+	return 0, true
+}
+
+// limit returns the rate limit of method, l.mu must be held.
+func (l *RateLimiter) limit(method string) RateLimit {
+	if limit, ok := l.limits[method]; ok {
+		return limit
+	}
+	if strings.HasPrefix(method, "public/") {
+		return DefaultPublicRateLimit
+	}
+
+	return DefaultPrivateRateLimit
+}
@@ -0,0 +1,68 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	const method = "private/some-method"
+
+	clock := clockwork.NewFakeClock()
+
+	limiter := api.NewRateLimiter(clock)
+	limiter.SetLimit(method, api.RateLimit{Requests: 2, Interval: time.Second})
+
+	ctx := context.Background()
+
+	// the burst is allowed immediately.
+	require.NoError(t, limiter.Wait(ctx, method))
+	require.NoError(t, limiter.Wait(ctx, method))
+
+	done := make(chan error)
+	go func() { done <- limiter.Wait(ctx, method) }()
+
+	clock.BlockUntil(1)
+
+	select {
+	case <-done:
+		t.Fatal("wait returned before a token was available")
+	default:
+	}
+
+	// a token is refilled every 500ms.
+	clock.Advance(500 * time.Millisecond)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for token")
+	}
+
+	t.Run("other methods have separate budgets", func(t *testing.T) {
+		assert.NoError(t, limiter.Wait(ctx, "public/get-book"))
+	})
+
+	t.Run("returns error when ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		assert.Equal(t, context.Canceled, limiter.Wait(ctx, method))
+	})
+
+	t.Run("does not limit methods with 0 requests", func(t *testing.T) {
+		limiter.SetLimit(method, api.RateLimit{})
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, limiter.Wait(ctx, method))
+		}
+	})
+}
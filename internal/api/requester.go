@@ -14,6 +14,8 @@ import (
 type Requester struct {
 	Client  *http.Client
 	BaseURL string
+	// RateLimiter is optional, requests are not limited if it is nil.
+	RateLimiter *RateLimiter
 }
 
 func (r Requester) Post(ctx context.Context, body Request, method string, response interface{}) (int, error) {
@@ -24,7 +26,24 @@ func (r Requester) Get(ctx context.Context, body Request, method string, respons
 	return r.doRequest(ctx, http.MethodGet, body, method, response)
 }
 
+// Wait blocks until the RateLimiter allows a request to be made for method, or ctx is done.
+func (r Requester) Wait(ctx context.Context, method string) error {
+	if r.RateLimiter == nil {
+		return nil
+	}
+
+	if err := r.RateLimiter.Wait(ctx, method); err != nil {
+		return fmt.Errorf("failed to wait for rate limit: %w", err)
+	}
+
+	return nil
+}
+
 func (r Requester) doRequest(ctx context.Context, httpMethod string, body Request, method string, response interface{}) (int, error) {
+	if err := r.Wait(ctx, method); err != nil {
+		return 0, err
+	}
+
 	b, err := json.Marshal(body)
 	if err != nil {
 		return 0, fmt.Errorf("failed to marshal request body: %w", err)
@@ -53,7 +72,12 @@ func (r Requester) doRequest(ctx context.Context, httpMethod string, body Reques
 	}
 
 	if err := json.Unmarshal(resBytes, &response); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal response body: %s, error: %w", string(resBytes), err)
+		err = fmt.Errorf("failed to unmarshal response body: %s, error: %w", string(resBytes), err)
+		if res.StatusCode >= 500 {
+			// e.g. an error page returned by a gateway, which has no response code.
+			return res.StatusCode, errors.ResponseError{HTTPStatusCode: res.StatusCode, Err: err}
+		}
+		return 0, err
 	}
 
 	return res.StatusCode, nil
@@ -97,6 +97,22 @@ func TestRequester_Post_Error(t *testing.T) {
 			},
 			expectedErr: errors.New("unexpected end of JSON input"),
 		},
+		{
+			name: "returns status code if invalid body returned with 5xx status",
+			args: args{
+				ctx:    context.Background(),
+				body:   api.Request{},
+				method: "some method",
+			},
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusBadGateway,
+					response:   "<html>bad gateway</html>",
+				},
+			},
+			expectedStatusCode: http.StatusBadGateway,
+			expectedErr:        errors.New("502 Bad Gateway: (0) failed to unmarshal response body"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
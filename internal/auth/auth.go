@@ -4,10 +4,19 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 )
 
+// MaxParamDepth is the number of levels of objects & arrays allowed in the params of a request, the params themselves
+// being the first level (e.g. the orders of private/create-order-list are on the second).
+const MaxParamDepth = 3
+
+// ErrParamsTooDeep is returned when params are nested deeper than MaxParamDepth.
+var ErrParamsTooDeep = errors.New("params are nested too deeply")
+
 type (
 	SignatureRequest struct {
 		APIKey    string
@@ -31,13 +40,16 @@ type (
 )
 
 func (g Generator) GenerateSignature(req SignatureRequest) (string, error) {
-	paramStr := g.buildParamString(req.Params)
+	paramStr, err := g.buildParamString(req.Params, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to build param string: %w", err)
+	}
 
 	signaturePayload := fmt.Sprintf("%s%d%s%s%d", req.Method, req.ID, req.APIKey, paramStr, req.Timestamp)
 
 	h := hmac.New(sha256.New, []byte(req.SecretKey))
 
-	_, err := h.Write([]byte(signaturePayload))
+	_, err = h.Write([]byte(signaturePayload))
 	if err != nil {
 		return "", fmt.Errorf("failed to write signature: %w", err)
 	}
@@ -45,18 +57,98 @@ func (g Generator) GenerateSignature(req SignatureRequest) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-func (g Generator) buildParamString(params map[string]interface{}) string {
+// buildParamString builds the param string of an object (at the given level of nesting) by concatenating each key,
+// sorted in ascending order, with the string of its value (see buildValueString).
+func (g Generator) buildParamString(params map[string]interface{}, level int) (string, error) {
 	if len(params) == 0 {
-		return ""
+		return "", nil
 	}
 
 	var paramsString string
 
 	for _, p := range g.sortParams(params) {
-		paramsString = fmt.Sprintf("%s%s%v", paramsString, p.key, p.val)
+		val, err := g.buildValueString(reflect.ValueOf(p.val), level)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", p.key, err)
+		}
+		paramsString += p.key + val
+	}
+
+	return paramsString, nil
+}
+
+// buildValueString builds the string of a param value (at the given level of nesting) following the Exchange's
+// signing rules:
+//   - nil values are "null"
+//   - objects are the param string of the object, one level deeper
+//   - arrays are the concatenated strings of each element, one level deeper (objects in arrays are not nested further)
+//   - any other value is formatted as is
+//
+// ErrParamsTooDeep is returned for objects & arrays nested MaxParamDepth or more levels deep.
+func (g Generator) buildValueString(v reflect.Value, level int) (string, error) {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid, reflect.Ptr, reflect.Interface:
+		return "null", nil
+	case reflect.Map:
+		if v.IsNil() {
+			return "null", nil
+		}
+		return g.buildObjectString(v, level+1)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "null", nil
+		}
+		if level >= MaxParamDepth {
+			return "", fmt.Errorf("%w (max %d levels)", ErrParamsTooDeep, MaxParamDepth)
+		}
+
+		var arrayString string
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			for (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+
+			var (
+				val string
+				err error
+			)
+			if elem.Kind() == reflect.Map && !elem.IsNil() {
+				val, err = g.buildObjectString(elem, level+1)
+			} else {
+				val, err = g.buildValueString(elem, level+1)
+			}
+			if err != nil {
+				return "", fmt.Errorf("[%d]: %w", i, err)
+			}
+			arrayString += val
+		}
+		return arrayString, nil
+	default:
+		return fmt.Sprintf("%v", v.Interface()), nil
+	}
+}
+
+// buildObjectString builds the param string of an object at the given level of nesting.
+func (g Generator) buildObjectString(v reflect.Value, level int) (string, error) {
+	if level >= MaxParamDepth {
+		return "", fmt.Errorf("%w (max %d levels)", ErrParamsTooDeep, MaxParamDepth)
+	}
+	if v.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("unsupported object key type: %s", v.Type().Key())
+	}
+
+	obj := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		obj[iter.Key().String()] = iter.Value().Interface()
 	}
 
-	return paramsString
+	return g.buildParamString(obj, level)
 }
 
 func (Generator) sortParams(params map[string]interface{}) []param {
@@ -0,0 +1,6 @@
+package auth
+
+// BuildParamString exposes buildParamString for tests.
+func (g Generator) BuildParamString(params map[string]interface{}) (string, error) {
+	return g.buildParamString(params, 0)
+}
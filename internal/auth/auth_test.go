@@ -0,0 +1,218 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+func TestGenerator_GenerateSignature(t *testing.T) {
+	const (
+		apiKey    = "api_key"
+		secretKey = "secret_key"
+		id        = int64(11)
+		timestamp = int64(1587846358253)
+	)
+
+	// the expected signatures are the HMAC-SHA256 of method + id + api key + param string + timestamp,
+	// with the param string built following the Exchange's signing rules.
+	tests := []struct {
+		name              string
+		method            string
+		params            map[string]interface{}
+		paramString       string
+		expectedSignature string
+	}{
+		{
+			name:              "signs request without params",
+			method:            "private/get-account-summary",
+			paramString:       "",
+			expectedSignature: "fa4ddb262b64566c548fe7df0198611ea843b16df37da22754b328b934f54236",
+		},
+		{
+			name:   "signs scalar params in key order",
+			method: "private/get-order-history",
+			params: map[string]interface{}{
+				"page_size":       20,
+				"instrument_name": "BTC_USDT",
+				"page":            0,
+			},
+			paramString:       "instrument_nameBTC_USDTpage0page_size20",
+			expectedSignature: "f0f2fd3785288babdc48e4263116b755d3d858f305d912d8d19a7dd20b3613a9",
+		},
+		{
+			name:   "signs array of objects",
+			method: "private/create-order-list",
+			params: map[string]interface{}{
+				"contingency_type": "LIST",
+				"order_list": []map[string]interface{}{
+					{
+						"instrument_name": "ETH_CRO",
+						"side":            "BUY",
+						"type":            "LIMIT",
+						"price":           "5799",
+						"quantity":        "1",
+						"client_oid":      "my_order_0001",
+					},
+					{
+						"instrument_name": "ETH_CRO",
+						"side":            "SELL",
+						"type":            "STOP_LIMIT",
+						"price":           "5800",
+						"quantity":        "1",
+						"trigger_price":   "5800.5",
+					},
+				},
+			},
+			paramString: "contingency_typeLIST" +
+				"order_list" +
+				"client_oidmy_order_0001instrument_nameETH_CROprice5799quantity1sideBUYtypeLIMIT" +
+				"instrument_nameETH_CROprice5800quantity1sideSELLtrigger_price5800.5typeSTOP_LIMIT",
+			expectedSignature: "84c296f3302b08038fae1d41142c1273fdcd8564c8be688d0f96ce4076c3562a",
+		},
+		{
+			name:   "signs nested objects, nested arrays & null values",
+			method: "private/some-method",
+			params: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"status":          []string{"ACTIVE", "FILLED"},
+					"instrument_name": "BTC_USDT",
+				},
+				"cursor": nil,
+				"flags":  []interface{}{"a", 2, true},
+				"deep": []interface{}{
+					[]map[string]string{{"x": "1"}},
+					[]string{"y"},
+				},
+			},
+			paramString:       "cursornulldeepx1yfilterinstrument_nameBTC_USDTstatusACTIVEFILLEDflagsa2true",
+			expectedSignature: "aac18cc08d2b5d8f7ef068673086e13b5afc77cf9f7a141181a5acf75b1bcd06",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paramString, err := auth.Generator{}.BuildParamString(tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.paramString, paramString)
+
+			signature, err := auth.Generator{}.GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    tt.method,
+				Timestamp: timestamp,
+				Params:    tt.params,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedSignature, signature)
+		})
+	}
+}
+
+// TestGenerator_GenerateSignature_ExchangeExample signs the private/create-order-list request of the Exchange's
+// "Digital Signature" example (with its placeholder keys & a fixed nonce), the expected signature being computed with
+// the Exchange's reference (Python) implementation rather than this package.
+func TestGenerator_GenerateSignature_ExchangeExample(t *testing.T) {
+	params := map[string]interface{}{
+		"contingency_type": "LIST",
+		"order_list": []map[string]interface{}{
+			{
+				"instrument_name": "ONE_USDT",
+				"side":            "BUY",
+				"type":            "LIMIT",
+				"price":           "0.24",
+				"quantity":        "1.0",
+			},
+			{
+				"instrument_name": "ONE_USDT",
+				"side":            "BUY",
+				"type":            "STOP_LIMIT",
+				"price":           "0.27",
+				"quantity":        "1.0",
+				"trigger_price":   "0.26",
+			},
+		},
+	}
+
+	paramString, err := auth.Generator{}.BuildParamString(params)
+	require.NoError(t, err)
+	assert.Equal(t, "contingency_typeLIST"+
+		"order_list"+
+		"instrument_nameONE_USDTprice0.24quantity1.0sideBUYtypeLIMIT"+
+		"instrument_nameONE_USDTprice0.27quantity1.0sideBUYtrigger_price0.26typeSTOP_LIMIT", paramString)
+
+	signature, err := auth.Generator{}.GenerateSignature(auth.SignatureRequest{
+		APIKey:    "API_KEY",
+		SecretKey: "SECRET_KEY",
+		ID:        14,
+		Method:    "private/create-order-list",
+		Timestamp: 1587846358253,
+		Params:    params,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0ce830395a52b741cd79a3f20d623de0eff72bfa9c6d87af37eba0cfafb51c6e", signature)
+}
+
+func TestGenerator_GenerateSignature_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		expectedErr error
+	}{
+		{
+			name: "returns error when objects are nested too deeply",
+			params: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{
+						"c": map[string]interface{}{},
+					},
+				},
+			},
+			expectedErr: auth.ErrParamsTooDeep,
+		},
+		{
+			name: "returns error when arrays of objects are nested too deeply",
+			params: map[string]interface{}{
+				"a": []map[string]interface{}{{
+					"b": []map[string]interface{}{{
+						"c": []map[string]interface{}{{"d": 1}},
+					}},
+				}},
+			},
+			expectedErr: auth.ErrParamsTooDeep,
+		},
+		{
+			name: "returns error when arrays are nested too deeply",
+			params: map[string]interface{}{
+				"a": [][][][]string{{{{"b"}}}},
+			},
+			expectedErr: auth.ErrParamsTooDeep,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := auth.Generator{}.GenerateSignature(auth.SignatureRequest{
+				Method: "private/some-method",
+				Params: tt.params,
+			})
+			require.Error(t, err)
+
+			assert.Empty(t, signature)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+		})
+	}
+
+	t.Run("returns error given self-referencing params", func(t *testing.T) {
+		params := map[string]interface{}{}
+		params["a"] = params
+
+		_, err := auth.Generator{}.GenerateSignature(auth.SignatureRequest{Params: params})
+		assert.True(t, errors.Is(err, auth.ErrParamsTooDeep))
+	})
+}
@@ -0,0 +1,243 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	MethodHeartbeat        = "public/heartbeat"
+	MethodRespondHeartbeat = "public/respond-heartbeat"
+	MethodSubscribe        = "subscribe"
+	MethodUnsubscribe      = "unsubscribe"
+)
+
+var ErrClosed = errors.New("websocket connection closed")
+
+type (
+	Request struct {
+		ID        int64                  `json:"id"`
+		Method    string                 `json:"method"`
+		Nonce     int64                  `json:"nonce,omitempty"`
+		Params    map[string]interface{} `json:"params,omitempty"`
+		Signature string                 `json:"sig,omitempty"`
+		APIKey    string                 `json:"api_key,omitempty"`
+	}
+
+	Response struct {
+		ID      int64           `json:"id"`
+		Method  string          `json:"method"`
+		Code    json.Number     `json:"code"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+
+	SubscriptionResult struct {
+		Subscription   string          `json:"subscription"`
+		Channel        string          `json:"channel"`
+		InstrumentName string          `json:"instrument_name"`
+		Data           json.RawMessage `json:"data"`
+	}
+
+	// Handler is called from the read loop for every subscription message received.
+	Handler func(SubscriptionResult)
+
+	// Conn is a single websocket connection to the Exchange.
+	// It responds to heartbeats, matches responses to requests by ID and
+	// passes subscription messages to its Handler.
+	Conn struct {
+		conn    *websocket.Conn
+		handler Handler
+
+		writeMu sync.Mutex
+
+		mu       sync.Mutex
+		pending  map[int64]chan Response
+		err      error
+		done     chan struct{}
+		activity chan struct{}
+	}
+)
+
+func Dial(ctx context.Context, dialer *websocket.Dialer, url string, handler Handler) (*Conn, error) {
+	conn, _, err := dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	c := &Conn{
+		conn:     conn,
+		handler:  handler,
+		pending:  make(map[int64]chan Response),
+		done:     make(chan struct{}),
+		activity: make(chan struct{}, 1),
+	}
+
+	go c.readLoop()
+
+	return c, nil
+}
+
+// Call sends req and waits for the response with the matching ID.
+func (c *Conn) Call(ctx context.Context, req Request) (*Response, error) {
+	ch := make(chan Response, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.pending[req.ID] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, req.ID)
+		c.mu.Unlock()
+	}()
+
+	if err := c.Send(req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-ch:
+		if err := res.Err(); err != nil {
+			return &res, fmt.Errorf("error received in response: %w", err)
+		}
+		return &res, nil
+	case <-c.done:
+		return nil, c.Err()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Send writes req without waiting for a response.
+func (c *Conn) Send(req Request) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.conn.WriteJSON(req); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
+
+// Done is closed once the connection has been closed or has failed.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Activity receives a value whenever a message is read from the connection.
+func (c *Conn) Activity() <-chan struct{} {
+	return c.activity
+}
+
+// Err returns the reason the connection was closed, if any.
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *Conn) Close() error {
+	return c.CloseWithError(ErrClosed)
+}
+
+// CloseWithError closes the connection, err is returned from Err.
+func (c *Conn) CloseWithError(err error) error {
+	c.writeMu.Lock()
+	_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+
+	c.shutdown(err)
+
+	return nil
+}
+
+func (c *Conn) readLoop() {
+	for {
+		_, b, err := c.conn.ReadMessage()
+		if err != nil {
+			c.shutdown(fmt.Errorf("failed to read message: %w", err))
+			return
+		}
+
+		select {
+		case c.activity <- struct{}{}:
+		default:
+		}
+
+		var res Response
+		if err := json.Unmarshal(b, &res); err != nil {
+			continue
+		}
+
+		if res.Method == MethodHeartbeat {
+			if err := c.Send(Request{ID: res.ID, Method: MethodRespondHeartbeat}); err != nil {
+				c.shutdown(err)
+				return
+			}
+			continue
+		}
+
+		c.deliver(res)
+
+		// the initial snapshot may arrive on the subscribe response itself.
+		if res.Method == MethodSubscribe && len(res.Result) > 0 {
+			var result SubscriptionResult
+			if err := json.Unmarshal(res.Result, &result); err != nil || result.Subscription == "" {
+				continue
+			}
+			c.handler(result)
+		}
+	}
+}
+
+func (c *Conn) deliver(res Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ch, ok := c.pending[res.ID]; ok {
+		ch <- res
+		delete(c.pending, res.ID)
+	}
+}
+
+func (c *Conn) shutdown(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	close(c.done)
+	c.conn.Close()
+}
+
+func (r Response) Err() error {
+	if r.Code == "" {
+		return nil
+	}
+
+	code, err := r.Code.Int64()
+	if err != nil {
+		return cdcerrors.ResponseError{
+			Err: fmt.Errorf("invalid response code: %v", r.Code),
+		}
+	}
+
+	return cdcerrors.NewResponseError(0, code)
+}
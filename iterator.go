@@ -0,0 +1,377 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+type (
+	// pageIterator pages through a paginated endpoint, stopping once the last page is fetched.
+	pageIterator struct {
+		// fetch gets the given page, buffering its items in the typed iterator and returning how many there are,
+		// and whether it is the last page.
+		fetch func(ctx context.Context, page int) (n int, done bool, err error)
+
+		// unit is the name of what each call to fetch gets, used in errors (Default: page).
+		unit string
+
+		page int // page is the next page to fetch.
+		n    int // n is the number of buffered items.
+		pos  int // pos is the index of the current item.
+		done bool
+		err  error
+	}
+
+	// OrderHistoryIterator iterates over each order returned from private/get-order-history, fetching pages as needed.
+	OrderHistoryIterator struct {
+		pageIterator
+		orders []Order
+	}
+
+	// OpenOrderIterator iterates over each order returned from private/get-open-orders, fetching pages as needed.
+	//
+	// Open orders may move between pages as they are filled or cancelled during iteration.
+	OpenOrderIterator struct {
+		pageIterator
+		orders []Order
+	}
+
+	// TradeIterator iterates over each trade returned from private/get-trades, fetching pages as needed.
+	TradeIterator struct {
+		pageIterator
+		trades []Trade
+	}
+
+	// DepositIterator iterates over each deposit returned from private/get-deposit-history, fetching pages as needed.
+	DepositIterator struct {
+		pageIterator
+		deposits []Deposit
+	}
+
+	// WithdrawalIterator iterates over each withdrawal returned from private/get-withdrawal-history, fetching pages as needed.
+	WithdrawalIterator struct {
+		pageIterator
+		withdrawals []Withdrawal
+	}
+
+	// DerivativesTransferIterator iterates over each transfer returned from private/deriv/get-transfer-history, fetching pages as needed.
+	DerivativesTransferIterator struct {
+		pageIterator
+		transfers []DerivativesTransferRecord
+	}
+)
+
+// next advances to the next item, fetching pages once the buffered items are exhausted.
+func (it *pageIterator) next(ctx context.Context) bool {
+	if it.pos+1 < it.n {
+		it.pos++
+		return true
+	}
+
+	for !it.done && it.err == nil {
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		n, done, err := it.fetch(ctx, it.page)
+		if err != nil {
+			unit := it.unit
+			if unit == "" {
+				unit = "page"
+			}
+			it.err = fmt.Errorf("failed to get %s %d: %w", unit, it.page, err)
+			return false
+		}
+
+		it.page++
+		it.n, it.pos, it.done = n, 0, done
+		if n > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// validateMax validates the max items cap of an All call.
+func validateMax(max int) error {
+	if max < 0 {
+		return errors.InvalidParameterError{Parameter: "max", Reason: "cannot be less than 0"}
+	}
+	return nil
+}
+
+// IterateOrderHistory returns an iterator over the order history, starting from req.Page.
+//
+// Method: private/get-order-history
+func (c *Client) IterateOrderHistory(req GetOrderHistoryRequest) *OrderHistoryIterator {
+	it := &OrderHistoryIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		orders, err := c.GetOrderHistory(ctx, req)
+		it.orders = orders
+		return len(orders), len(orders) == 0, err
+	}
+	return it
+}
+
+// Next advances to the next order, returning false once every page has been read or an error occurs (see Err).
+func (it *OrderHistoryIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Order returns the current order.
+func (it *OrderHistoryIterator) Order() Order {
+	return it.orders[it.pos]
+}
+
+// All reads the remaining orders into a slice, stopping once max orders have been read.
+//
+// if max is 0, every remaining order is read.
+func (it *OrderHistoryIterator) All(ctx context.Context, max int) ([]Order, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	for (max == 0 || len(orders) < max) && it.Next(ctx) {
+		orders = append(orders, it.Order())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// IterateOpenOrders returns an iterator over the open orders, starting from req.Page.
+//
+// Method: private/get-open-orders
+func (c *Client) IterateOpenOrders(req GetOpenOrdersRequest) *OpenOrderIterator {
+	it := &OpenOrderIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		res, err := c.GetOpenOrders(ctx, req)
+		if err != nil {
+			return 0, false, err
+		}
+		it.orders = res.OrderList
+		return len(res.OrderList), len(res.OrderList) == 0, nil
+	}
+	return it
+}
+
+// Next advances to the next order, returning false once every page has been read or an error occurs (see Err).
+func (it *OpenOrderIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Order returns the current order.
+func (it *OpenOrderIterator) Order() Order {
+	return it.orders[it.pos]
+}
+
+// All reads the remaining orders into a slice, stopping once max orders have been read.
+//
+// if max is 0, every remaining order is read.
+func (it *OpenOrderIterator) All(ctx context.Context, max int) ([]Order, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	for (max == 0 || len(orders) < max) && it.Next(ctx) {
+		orders = append(orders, it.Order())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// IterateTrades returns an iterator over the executed trades, starting from req.Page.
+//
+// Method: private/get-trades
+func (c *Client) IterateTrades(req GetTradesRequest) *TradeIterator {
+	it := &TradeIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		trades, err := c.GetTrades(ctx, req)
+		it.trades = trades
+		return len(trades), len(trades) == 0, err
+	}
+	return it
+}
+
+// Next advances to the next trade, returning false once every page has been read or an error occurs (see Err).
+func (it *TradeIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Trade returns the current trade.
+func (it *TradeIterator) Trade() Trade {
+	return it.trades[it.pos]
+}
+
+// All reads the remaining trades into a slice, stopping once max trades have been read.
+//
+// if max is 0, every remaining trade is read.
+func (it *TradeIterator) All(ctx context.Context, max int) ([]Trade, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var trades []Trade
+	for (max == 0 || len(trades) < max) && it.Next(ctx) {
+		trades = append(trades, it.Trade())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// IterateDepositHistory returns an iterator over the deposit history, starting from req.Page.
+//
+// Method: private/get-deposit-history
+func (c *Client) IterateDepositHistory(req GetDepositHistoryRequest) *DepositIterator {
+	it := &DepositIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		deposits, err := c.GetDepositHistory(ctx, req)
+		it.deposits = deposits
+		return len(deposits), len(deposits) == 0, err
+	}
+	return it
+}
+
+// Next advances to the next deposit, returning false once every page has been read or an error occurs (see Err).
+func (it *DepositIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Deposit returns the current deposit.
+func (it *DepositIterator) Deposit() Deposit {
+	return it.deposits[it.pos]
+}
+
+// All reads the remaining deposits into a slice, stopping once max deposits have been read.
+//
+// if max is 0, every remaining deposit is read.
+func (it *DepositIterator) All(ctx context.Context, max int) ([]Deposit, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var deposits []Deposit
+	for (max == 0 || len(deposits) < max) && it.Next(ctx) {
+		deposits = append(deposits, it.Deposit())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+// IterateWithdrawalHistory returns an iterator over the withdrawal history, starting from req.Page.
+//
+// Method: private/get-withdrawal-history
+func (c *Client) IterateWithdrawalHistory(req GetWithdrawalHistoryRequest) *WithdrawalIterator {
+	it := &WithdrawalIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		withdrawals, err := c.GetWithdrawalHistory(ctx, req)
+		it.withdrawals = withdrawals
+		return len(withdrawals), len(withdrawals) == 0, err
+	}
+	return it
+}
+
+// Next advances to the next withdrawal, returning false once every page has been read or an error occurs (see Err).
+func (it *WithdrawalIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Withdrawal returns the current withdrawal.
+func (it *WithdrawalIterator) Withdrawal() Withdrawal {
+	return it.withdrawals[it.pos]
+}
+
+// All reads the remaining withdrawals into a slice, stopping once max withdrawals have been read.
+//
+// if max is 0, every remaining withdrawal is read.
+func (it *WithdrawalIterator) All(ctx context.Context, max int) ([]Withdrawal, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var withdrawals []Withdrawal
+	for (max == 0 || len(withdrawals) < max) && it.Next(ctx) {
+		withdrawals = append(withdrawals, it.Withdrawal())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
+
+// IterateDerivativesTransferHistory returns an iterator over the derivatives transfer history, starting from req.Page.
+//
+// Method: private/deriv/get-transfer-history
+func (c *Client) IterateDerivativesTransferHistory(req GetDerivativesTransferHistoryRequest) *DerivativesTransferIterator {
+	it := &DerivativesTransferIterator{}
+	it.page = req.Page
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		req.Page = page
+		transfers, err := c.GetDerivativesTransferHistory(ctx, req)
+		it.transfers = transfers
+		return len(transfers), len(transfers) == 0, err
+	}
+	return it
+}
+
+// Next advances to the next transfer, returning false once every page has been read or an error occurs (see Err).
+func (it *DerivativesTransferIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// Transfer returns the current transfer.
+func (it *DerivativesTransferIterator) Transfer() DerivativesTransferRecord {
+	return it.transfers[it.pos]
+}
+
+// All reads the remaining transfers into a slice, stopping once max transfers have been read.
+//
+// if max is 0, every remaining transfer is read.
+func (it *DerivativesTransferIterator) All(ctx context.Context, max int) ([]DerivativesTransferRecord, error) {
+	if err := validateMax(max); err != nil {
+		return nil, err
+	}
+
+	var transfers []DerivativesTransferRecord
+	for (max == 0 || len(transfers) < max) && it.Next(ctx) {
+		transfers = append(transfers, it.Transfer())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,226 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// pageServer serves pages[page] as the list of the method's result, or an empty list once the pages are exhausted.
+// It returns a Client which sends requests to the server, and a func returning the pages requested.
+func pageServer(t *testing.T, method string, list string, pages ...string) (*cdcexchange.Client, func() []int) {
+	s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		assert.Equal(t, method, req.Method)
+
+		var items string
+		if page := int(req.Params["page"].(float64)); page < len(pages) {
+			items = pages[page]
+		}
+
+		return okResponse(fmt.Sprintf(`{"%s":[%s]}`, list, items))
+	})
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		// the history methods are limited to 1 request per second.
+		cdcexchange.WithRateLimit(method, cdcexchange.RateLimit{}),
+	)
+	require.NoError(t, err)
+
+	return client, func() []int {
+		var requested []int
+		for _, req := range requests() {
+			requested = append(requested, int(req.Params["page"].(float64)))
+		}
+		return requested
+	}
+}
+
+func TestClient_IterateTrades(t *testing.T) {
+	ctx := context.Background()
+	pages := []string{
+		`{"trade_id":"1"},{"trade_id":"2"}`,
+		`{"trade_id":"3"},{"trade_id":"4"}`,
+		`{"trade_id":"5"}`,
+	}
+
+	t.Run("pages until an empty page is returned", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		it := client.IterateTrades(cdcexchange.GetTradesRequest{PageSize: 2})
+
+		var ids []string
+		for it.Next(ctx) {
+			ids = append(ids, it.Trade().TradeID)
+		}
+		require.NoError(t, it.Err())
+
+		assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+		assert.Equal(t, []int{0, 1, 2, 3}, requested())
+
+		// the iterator stays exhausted.
+		assert.False(t, it.Next(ctx))
+		assert.Len(t, requested(), 4)
+	})
+
+	t.Run("starts from req.Page", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		trades, err := client.IterateTrades(cdcexchange.GetTradesRequest{Page: 1}).All(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, trades, 3)
+		assert.Equal(t, "3", trades[0].TradeID)
+		assert.Equal(t, []int{1, 2, 3}, requested())
+	})
+
+	t.Run("all stops once max items are read", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		it := client.IterateTrades(cdcexchange.GetTradesRequest{})
+
+		trades, err := it.All(ctx, 3)
+		require.NoError(t, err)
+
+		require.Len(t, trades, 3)
+		assert.Equal(t, "3", trades[2].TradeID)
+		assert.Equal(t, []int{0, 1}, requested())
+
+		// the remaining items can still be read.
+		trades, err = it.All(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, trades, 2)
+		assert.Equal(t, "4", trades[0].TradeID)
+		assert.Equal(t, "5", trades[1].TradeID)
+	})
+
+	t.Run("returns error when max is less than 0", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		trades, err := client.IterateTrades(cdcexchange.GetTradesRequest{}).All(ctx, -1)
+		require.Error(t, err)
+
+		assert.Nil(t, trades)
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "max", Reason: "cannot be less than 0"}, err)
+		assert.Empty(t, requested())
+	})
+
+	t.Run("returns error once ctx is done", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		it := client.IterateTrades(cdcexchange.GetTradesRequest{})
+
+		require.True(t, it.Next(ctx))
+		cancel()
+
+		// the buffered page can still be read.
+		require.True(t, it.Next(ctx))
+		assert.False(t, it.Next(ctx))
+
+		assert.True(t, errors.Is(it.Err(), context.Canceled))
+		assert.Equal(t, []int{0}, requested())
+	})
+
+	t.Run("returns error from request", func(t *testing.T) {
+		client, requested := pageServer(t, cdcexchange.MethodGetTrades, "trade_list", pages...)
+
+		trades, err := client.IterateTrades(cdcexchange.GetTradesRequest{PageSize: 201}).All(ctx, 0)
+		require.Error(t, err)
+
+		assert.Nil(t, trades)
+		assert.True(t, errors.Is(err, cdcerrors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be greater than 200"}))
+		assert.Empty(t, requested())
+	})
+}
+
+func TestClient_IterateOrderHistory(t *testing.T) {
+	client, requested := pageServer(t, cdcexchange.MethodGetOrderHistory, "order_list",
+		`{"order_id":"1"},{"order_id":"2"}`,
+		`{"order_id":"3"}`,
+	)
+
+	orders, err := client.IterateOrderHistory(cdcexchange.GetOrderHistoryRequest{InstrumentName: "BTC_USDT"}).All(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 3)
+	assert.Equal(t, "1", orders[0].OrderID)
+	assert.Equal(t, "3", orders[2].OrderID)
+	assert.Equal(t, []int{0, 1, 2}, requested())
+}
+
+func TestClient_IterateOpenOrders(t *testing.T) {
+	client, requested := pageServer(t, cdcexchange.MethodGetOpenOrders, "order_list",
+		`{"order_id":"1"},{"order_id":"2"}`,
+		`{"order_id":"3"}`,
+	)
+
+	it := client.IterateOpenOrders(cdcexchange.GetOpenOrdersRequest{})
+
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Order().OrderID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []int{0, 1, 2}, requested())
+}
+
+func TestClient_IterateDepositHistory(t *testing.T) {
+	client, requested := pageServer(t, cdcexchange.MethodGetDepositHistory, "deposit_list",
+		`{"id":"1"},{"id":"2"}`,
+		`{"id":"3"}`,
+	)
+
+	deposits, err := client.IterateDepositHistory(cdcexchange.GetDepositHistoryRequest{}).All(context.Background(), 2)
+	require.NoError(t, err)
+
+	require.Len(t, deposits, 2)
+	assert.Equal(t, "2", deposits[1].Id)
+	assert.Equal(t, []int{0}, requested())
+}
+
+func TestClient_IterateWithdrawalHistory(t *testing.T) {
+	client, requested := pageServer(t, cdcexchange.MethodGetWithdrawalHistory, "withdrawal_list",
+		`{"id":"1"},{"id":"2"}`,
+		`{"id":"3"}`,
+	)
+
+	it := client.IterateWithdrawalHistory(cdcexchange.GetWithdrawalHistoryRequest{Currency: "BTC"})
+
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Withdrawal().Id)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []int{0, 1, 2}, requested())
+}
+
+func TestClient_IterateDerivativesTransferHistory(t *testing.T) {
+	client, requested := pageServer(t, cdcexchange.MethodGetDerivativesTransferHistory, "transfer_list",
+		`{"direction":"IN","amount":1},{"direction":"OUT","amount":2}`,
+		`{"direction":"IN","amount":3}`,
+	)
+
+	transfers, err := client.IterateDerivativesTransferHistory(cdcexchange.GetDerivativesTransferHistoryRequest{Currency: "USDT"}).All(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, transfers, 3)
+	assert.Equal(t, cdcexchange.TransferDirectionOut, transfers[1].Direction)
+	assert.Equal(t, "3", transfers[2].Amount.String())
+	assert.Equal(t, []int{0, 1, 2}, requested())
+}
@@ -0,0 +1,97 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodMarginBorrow = "private/margin/borrow"
+)
+
+type (
+	// MarginBorrowRequest is the request params sent for the private/margin/borrow API.
+	MarginBorrowRequest struct {
+		// Currency is the currency symbol to borrow (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// Amount is the amount to borrow.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// MarginBorrowResponse is the base response returned from the private/margin/borrow API.
+	MarginBorrowResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// MarginBorrow borrows funds against the balance of the margin account.
+//
+// Interest accrues on the loan until it is repaid.
+//
+// Method: private/margin/borrow
+func (c *Client) MarginBorrow(ctx context.Context, req MarginBorrowRequest) error {
+	if req.Currency == "" {
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	}
+	if !req.Amount.IsPositive() {
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["currency"] = req.Currency
+	params["amount"] = req.Amount.String()
+
+	var marginBorrowResponse MarginBorrowResponse
+	err := c.retry(ctx, false, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodMarginBorrow,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodMarginBorrow,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		marginBorrowResponse = MarginBorrowResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodMarginBorrow, &marginBorrowResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, marginBorrowResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const methodCancelMarginOrder = "private/margin/cancel-order"
+
+// CancelMarginOrder cancels an existing margin order on the Exchange.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// Method: private/margin/cancel-order
+func (c *Client) CancelMarginOrder(ctx context.Context, instrumentName string, orderID string) error {
+	if instrumentName == "" {
+		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
+	}
+	if orderID == "" {
+		return errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["instrument_name"] = instrumentName
+	params["order_id"] = orderID
+
+	var cancelOrderResponse CancelOrderResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCancelMarginOrder,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodCancelMarginOrder,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		cancelOrderResponse = CancelOrderResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCancelMarginOrder, &cancelOrderResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, cancelOrderResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodCreateMarginOrder = "private/margin/create-order"
+)
+
+// CreateMarginOrder creates a new BUY or SELL order on the Exchange, using the margin account.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// If the Client was created WithOrderValidation, req is validated against its instrument before being sent.
+//
+// Method: private/margin/create-order
+func (c *Client) CreateMarginOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
+	if err := c.validateOrder(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to validate order: %w", err)
+	}
+
+	params := createOrderParams(req)
+
+	var createOrderResponse CreateOrderResponse
+	err := c.retry(ctx, req.ClientOID != "", func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodCreateMarginOrder,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodCreateMarginOrder,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		createOrderResponse = CreateOrderResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodCreateMarginOrder, &createOrderResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, createOrderResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &createOrderResponse.Result, nil
+}
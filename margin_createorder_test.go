@@ -0,0 +1,262 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_CreateMarginOrder_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		instrumentName = "BTC_USDT"
+		clientOID      = "some client oid"
+		orderID        = "some order id"
+	)
+	var (
+		testErr  = errors.New("some error")
+		price    = decimal.RequireFromString("20000.5")
+		quantity = decimal.RequireFromString("0.01")
+	)
+
+	type args struct {
+		req cdcexchange.CreateOrderRequest
+	}
+	validArgs := args{
+		req: cdcexchange.CreateOrderRequest{
+			InstrumentName: instrumentName,
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeLimit,
+			Price:          price,
+			Quantity:       quantity,
+			ClientOID:      clientOID,
+		},
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodCreateMarginOrder,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"instrument_name": instrumentName,
+						"side":            cdcexchange.OrderSideBuy,
+						"type":            cdcexchange.OrderTypeLimit,
+						"price":           price.String(),
+						"quantity":        quantity.String(),
+						"client_oid":      clientOID,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.CreateMarginOrder(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_CreateMarginOrder_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrumentName = "BTC_USDT"
+		clientOID      = "some client oid"
+		orderID        = "some order id"
+	)
+	var (
+		now      = time.Now().Round(time.Second)
+		price    = decimal.RequireFromString("20000.5")
+		quantity = decimal.RequireFromString("0.01")
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateMarginOrder)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodCreateMarginOrder, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, instrumentName, body.Params["instrument_name"])
+		assert.Equal(t, string(cdcexchange.OrderSideBuy), body.Params["side"])
+		assert.Equal(t, string(cdcexchange.OrderTypeLimit), body.Params["type"])
+		assert.Equal(t, price.String(), body.Params["price"])
+		assert.Equal(t, quantity.String(), body.Params["quantity"])
+		assert.Equal(t, clientOID, body.Params["client_oid"])
+
+		res := `{
+			"id": 0,
+			"method":"",
+			"code":0,
+			"result":{
+				"order_id":"some order id",
+				"client_oid":"some client oid"
+			}
+		}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	req := cdcexchange.CreateOrderRequest{
+		InstrumentName: instrumentName,
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          price,
+		Quantity:       quantity,
+		ClientOID:      clientOID,
+	}
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodCreateMarginOrder,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"instrument_name": instrumentName,
+			"side":            cdcexchange.OrderSideBuy,
+			"type":            cdcexchange.OrderTypeLimit,
+			"price":           price.String(),
+			"quantity":        quantity.String(),
+			"client_oid":      clientOID,
+		},
+	}).Return(signature, nil)
+
+	res, err := client.CreateMarginOrder(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, &cdcexchange.CreateOrderResult{
+		OrderID:   orderID,
+		ClientOID: clientOID,
+	}, res)
+}
@@ -0,0 +1,139 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodGetMarginAccountSummary = "private/margin/get-account-summary"
+)
+
+type (
+	// MarginAccountSummaryResponse is the base response returned from the private/margin/get-account-summary API.
+	MarginAccountSummaryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result MarginAccountSummaryResult `json:"result"`
+	}
+
+	// MarginAccountSummaryResult is the result returned from the private/margin/get-account-summary API.
+	MarginAccountSummaryResult struct {
+		// Accounts is the returned margin account data.
+		Accounts []MarginAccount `json:"accounts"`
+		// TotalBalance is the total balance of the margin account, in Currency.
+		TotalBalance decimal.Decimal `json:"total_balance"`
+		// TotalBalanceBtc is the total balance of the margin account, in BTC.
+		TotalBalanceBtc decimal.Decimal `json:"total_balance_btc"`
+		// EquityValue is the total balance less the total borrowed & accrued interest, in Currency.
+		EquityValue decimal.Decimal `json:"equity_value"`
+		// EquityValueBtc is the total balance less the total borrowed & accrued interest, in BTC.
+		EquityValueBtc decimal.Decimal `json:"equity_value_btc"`
+		// TotalBorrowed is the total amount borrowed, in Currency.
+		TotalBorrowed decimal.Decimal `json:"total_borrowed"`
+		// TotalBorrowedBtc is the total amount borrowed, in BTC.
+		TotalBorrowedBtc decimal.Decimal `json:"total_borrowed_btc"`
+		// TotalAccruedInterest is the total interest accrued on loans, in Currency.
+		TotalAccruedInterest decimal.Decimal `json:"total_accrued_interest"`
+		// TotalAccruedInterestBtc is the total interest accrued on loans, in BTC.
+		TotalAccruedInterestBtc decimal.Decimal `json:"total_accrued_interest_btc"`
+		// MarginScore is the margin score of the account (e.g. GOOD, FAIR, POOR or LIQUIDATING).
+		MarginScore string `json:"margin_score"`
+		// Currency is the home currency that totals are valued in (e.g. USDT).
+		Currency string `json:"currency"`
+	}
+
+	// MarginAccount represents margin balance details of a specific token.
+	MarginAccount struct {
+		// Balance is the total balance (Available + Order).
+		Balance decimal.Decimal `json:"balance"`
+		// Available is the available balance (e.g. not in orders).
+		Available decimal.Decimal `json:"available"`
+		// Order is the balance locked in orders.
+		Order decimal.Decimal `json:"order"`
+		// Borrowed is the amount borrowed.
+		Borrowed decimal.Decimal `json:"borrowed"`
+		// Position is the net position (Balance - Borrowed - AccruedInterest).
+		Position decimal.Decimal `json:"position"`
+		// PositionHomeCurrency is the net position, in the home currency.
+		PositionHomeCurrency decimal.Decimal `json:"positionHomeCurrency"`
+		// PositionBtc is the net position, in BTC.
+		PositionBtc decimal.Decimal `json:"positionBtc"`
+		// LastPriceHomeCurrency is the last price of the token, in the home currency.
+		LastPriceHomeCurrency decimal.Decimal `json:"lastPriceHomeCurrency"`
+		// LastPriceBtc is the last price of the token, in BTC.
+		LastPriceBtc decimal.Decimal `json:"lastPriceBtc"`
+		// Currency is the symbol for the currency (e.g. CRO).
+		Currency string `json:"currency"`
+		// AccruedInterest is the interest accrued on the amount borrowed.
+		AccruedInterest decimal.Decimal `json:"accrued_interest"`
+		// LiquidationPrice is the price of the token at which the account would be liquidated.
+		LiquidationPrice decimal.Decimal `json:"liquidation_price"`
+	}
+)
+
+// GetMarginAccountSummary returns the margin account balance of a user for a particular token.
+//
+// currency can be left blank to retrieve balances for ALL tokens.
+//
+// Method: private/margin/get-account-summary
+func (c *Client) GetMarginAccountSummary(ctx context.Context, currency string) (*MarginAccountSummaryResult, error) {
+	params := make(map[string]interface{})
+
+	// if currency is omitted, ALL currencies are returned.
+	if currency != "" {
+		params["currency"] = currency
+	}
+
+	var marginAccountSummaryResponse MarginAccountSummaryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetMarginAccountSummary,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetMarginAccountSummary,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		marginAccountSummaryResponse = MarginAccountSummaryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetMarginAccountSummary, &marginAccountSummaryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, marginAccountSummaryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &marginAccountSummaryResponse.Result, nil
+}
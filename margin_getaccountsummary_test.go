@@ -0,0 +1,267 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_GetMarginAccountSummary_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		currency = "BTC"
+	)
+	testErr := errors.New("some error")
+
+	type args struct {
+		currency string
+	}
+	validArgs := args{
+		currency: currency,
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetMarginAccountSummary,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency": currency,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.GetMarginAccountSummary(ctx, tt.currency)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetMarginAccountSummary_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		currency = "BTC"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetMarginAccountSummary)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetMarginAccountSummary, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, currency, body.Params["currency"])
+
+		res := `{
+			"id": 0,
+			"method":"",
+			"code":0,
+			"result":{
+				"accounts":[
+					{
+						"balance":1.5,
+						"available":1,
+						"order":0.5,
+						"borrowed":0.2,
+						"position":1.29,
+						"positionHomeCurrency":25800,
+						"positionBtc":1.29,
+						"lastPriceHomeCurrency":20000,
+						"lastPriceBtc":1,
+						"currency":"BTC",
+						"accrued_interest":0.01,
+						"liquidation_price":9000
+					}
+				],
+				"total_balance":30000,
+				"total_balance_btc":1.5,
+				"equity_value":25800,
+				"equity_value_btc":1.29,
+				"total_borrowed":4000,
+				"total_borrowed_btc":0.2,
+				"total_accrued_interest":200,
+				"total_accrued_interest_btc":0.01,
+				"margin_score":"GOOD",
+				"currency":"USDT"
+			}
+		}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetMarginAccountSummary,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"currency": currency,
+		},
+	}).Return(signature, nil)
+
+	res, err := client.GetMarginAccountSummary(ctx, currency)
+	require.NoError(t, err)
+
+	assert.Equal(t, &cdcexchange.MarginAccountSummaryResult{
+		Accounts: []cdcexchange.MarginAccount{
+			{
+				Balance:               decimal.RequireFromString("1.5"),
+				Available:             decimal.NewFromInt(1),
+				Order:                 decimal.RequireFromString("0.5"),
+				Borrowed:              decimal.RequireFromString("0.2"),
+				Position:              decimal.RequireFromString("1.29"),
+				PositionHomeCurrency:  decimal.NewFromInt(25800),
+				PositionBtc:           decimal.RequireFromString("1.29"),
+				LastPriceHomeCurrency: decimal.NewFromInt(20000),
+				LastPriceBtc:          decimal.NewFromInt(1),
+				Currency:              currency,
+				AccruedInterest:       decimal.RequireFromString("0.01"),
+				LiquidationPrice:      decimal.NewFromInt(9000),
+			},
+		},
+		TotalBalance:            decimal.NewFromInt(30000),
+		TotalBalanceBtc:         decimal.RequireFromString("1.5"),
+		EquityValue:             decimal.NewFromInt(25800),
+		EquityValueBtc:          decimal.RequireFromString("1.29"),
+		TotalBorrowed:           decimal.NewFromInt(4000),
+		TotalBorrowedBtc:        decimal.RequireFromString("0.2"),
+		TotalAccruedInterest:    decimal.NewFromInt(200),
+		TotalAccruedInterestBtc: decimal.RequireFromString("0.01"),
+		MarginScore:             "GOOD",
+		Currency:                "USDT",
+	}, res)
+}
@@ -0,0 +1,154 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	methodGetMarginBorrowHistory = "private/margin/get-borrow-history"
+)
+
+type (
+	// GetMarginBorrowHistoryRequest is the request params sent for the private/margin/get-borrow-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	//
+	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
+	GetMarginBorrowHistoryRequest struct {
+		// Currency represents the currency symbol for the loans (e.g. BTC or USDT).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of loans returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginBorrowHistoryResponse is the base response returned from the private/margin/get-borrow-history API.
+	GetMarginBorrowHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginBorrowHistoryResult `json:"result"`
+	}
+
+	// GetMarginBorrowHistoryResult is the result returned from the private/margin/get-borrow-history API.
+	GetMarginBorrowHistoryResult struct {
+		// BorrowList is the array of loans.
+		BorrowList []MarginLoan `json:"borrow_list"`
+	}
+
+	// MarginLoan represents the details of a margin loan.
+	MarginLoan struct {
+		// LoanID is the unique identifier for the loan.
+		LoanID string `json:"loan_id"`
+		// Currency is the currency symbol of the loan (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// LoanAmount is the amount borrowed.
+		LoanAmount decimal.Decimal `json:"loan_amount"`
+		// BorrowTime is the time the loan was borrowed.
+		BorrowTime cdctime.Time `json:"borrow_time"`
+		// Status is the status of the loan (e.g. ACTIVE or REPAID).
+		Status string `json:"status"`
+	}
+)
+
+// GetMarginBorrowHistory gets the margin loan history for a particular currency.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, enumerate each page (starting with 0) until an empty borrow_list array appears in the response.
+//
+// req.Currency can be left blank to get loans for all currencies.
+//
+// Method: private/margin/get-borrow-history
+func (c *Client) GetMarginBorrowHistory(ctx context.Context, req GetMarginBorrowHistoryRequest) ([]MarginLoan, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+	}
+
+	params := make(map[string]interface{})
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	var getMarginBorrowHistoryResponse GetMarginBorrowHistoryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetMarginBorrowHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetMarginBorrowHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getMarginBorrowHistoryResponse = GetMarginBorrowHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetMarginBorrowHistory, &getMarginBorrowHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getMarginBorrowHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getMarginBorrowHistoryResponse.Result.BorrowList, nil
+}
@@ -0,0 +1,154 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	methodGetMarginInterestHistory = "private/margin/get-interest-history"
+)
+
+type (
+	// GetMarginInterestHistoryRequest is the request params sent for the private/margin/get-interest-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	//
+	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
+	GetMarginInterestHistoryRequest struct {
+		// Currency represents the currency symbol for the interest charges (e.g. BTC or USDT).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of interest charges returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetMarginInterestHistoryResponse is the base response returned from the private/margin/get-interest-history API.
+	GetMarginInterestHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetMarginInterestHistoryResult `json:"result"`
+	}
+
+	// GetMarginInterestHistoryResult is the result returned from the private/margin/get-interest-history API.
+	GetMarginInterestHistoryResult struct {
+		// InterestList is the array of interest charges.
+		InterestList []MarginInterest `json:"interest_list"`
+	}
+
+	// MarginInterest represents interest charged on a margin loan.
+	MarginInterest struct {
+		// LoanID is the unique identifier for the loan the interest was charged on.
+		LoanID string `json:"loan_id"`
+		// Currency is the currency symbol of the interest (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// Interest is the amount of interest charged.
+		Interest decimal.Decimal `json:"interest"`
+		// InterestRate is the rate the interest was charged at.
+		InterestRate decimal.Decimal `json:"interest_rate"`
+		// Time is the time the interest was charged.
+		Time cdctime.Time `json:"time"`
+	}
+)
+
+// GetMarginInterestHistory gets the interest charged on margin loans of a particular currency.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, enumerate each page (starting with 0) until an empty interest_list array appears in the response.
+//
+// req.Currency can be left blank to get interest charges for all currencies.
+//
+// Method: private/margin/get-interest-history
+func (c *Client) GetMarginInterestHistory(ctx context.Context, req GetMarginInterestHistoryRequest) ([]MarginInterest, error) {
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+	}
+
+	params := make(map[string]interface{})
+
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	var getMarginInterestHistoryResponse GetMarginInterestHistoryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetMarginInterestHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetMarginInterestHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getMarginInterestHistoryResponse = GetMarginInterestHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetMarginInterestHistory, &getMarginInterestHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getMarginInterestHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getMarginInterestHistoryResponse.Result.InterestList, nil
+}
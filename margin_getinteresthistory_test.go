@@ -0,0 +1,296 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+func TestClient_GetMarginInterestHistory_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		currency = "BTC"
+	)
+	var (
+		testErr = errors.New("some error")
+		start   = time.Now().Add(-time.Hour).Round(time.Second)
+		end     = start.Add(time.Hour)
+	)
+
+	type args struct {
+		req cdcexchange.GetMarginInterestHistoryRequest
+	}
+	validArgs := args{
+		req: cdcexchange.GetMarginInterestHistoryRequest{
+			Currency: currency,
+			Start:    start,
+			End:      end,
+			PageSize: 50,
+			Page:     1,
+		},
+	}
+
+	tests := []struct {
+		name string
+		args
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name: "returns error when page size is less than 0",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{PageSize: -1},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.PageSize",
+				Reason:    "cannot be less than 0",
+			},
+		},
+		{
+			name: "returns error when page size is greater than 200",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{PageSize: 201},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.PageSize",
+				Reason:    "cannot be greater than 200",
+			},
+		},
+		{
+			name: "returns error when end is before start",
+			args: args{
+				req: cdcexchange.GetMarginInterestHistoryRequest{Start: end, End: start},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{
+				Parameter: "req.End",
+				Reason:    "cannot be before req.Start",
+			},
+		},
+		{
+			name:         "returns error given error generating signature",
+			args:         validArgs,
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			args: validArgs,
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			var invalidParameterErr cdcerrors.InvalidParameterError
+			if !errors.As(tt.expectedErr, &invalidParameterErr) {
+				idGenerator.EXPECT().Generate().Return(id)
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        id,
+					Method:    cdcexchange.MethodGetMarginInterestHistory,
+					Timestamp: now.UnixMilli(),
+					Params: map[string]interface{}{
+						"currency":  currency,
+						"start_ts":  start.UnixMilli(),
+						"end_ts":    end.UnixMilli(),
+						"page_size": 50,
+						"page":      1,
+					},
+				}).Return("signature", tt.signatureErr)
+			}
+
+			res, err := client.GetMarginInterestHistory(ctx, tt.req)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetMarginInterestHistory_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		currency = "BTC"
+	)
+	var (
+		now   = time.Now().Round(time.Second)
+		start = time.Now().Add(-time.Hour).Round(time.Second)
+		end   = start.Add(time.Hour)
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetMarginInterestHistory)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetMarginInterestHistory, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Equal(t, currency, body.Params["currency"])
+		assert.Equal(t, float64(start.UnixMilli()), body.Params["start_ts"])
+		assert.Equal(t, float64(end.UnixMilli()), body.Params["end_ts"])
+		assert.Equal(t, float64(50), body.Params["page_size"])
+		assert.Equal(t, float64(1), body.Params["page"])
+
+		res := fmt.Sprintf(`{
+			"id": 0,
+			"method":"",
+			"code":0,
+			"result":{
+				"interest_list":[
+					{
+						"loan_id":"1234",
+						"currency":"BTC",
+						"interest":0.0001,
+						"interest_rate":0.0002,
+						"time":%d
+					}
+				]
+			}
+		}`, now.UnixMilli())
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	req := cdcexchange.GetMarginInterestHistoryRequest{
+		Currency: currency,
+		Start:    start,
+		End:      end,
+		PageSize: 50,
+		Page:     1,
+	}
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetMarginInterestHistory,
+		Timestamp: now.UnixMilli(),
+		Params: map[string]interface{}{
+			"currency":  currency,
+			"start_ts":  start.UnixMilli(),
+			"end_ts":    end.UnixMilli(),
+			"page_size": 50,
+			"page":      1,
+		},
+	}).Return(signature, nil)
+
+	res, err := client.GetMarginInterestHistory(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []cdcexchange.MarginInterest{
+		{
+			LoanID:       "1234",
+			Currency:     currency,
+			Interest:     decimal.RequireFromString("0.0001"),
+			InterestRate: decimal.RequireFromString("0.0002"),
+			Time:         cdctime.Time(now),
+		},
+	}, res)
+}
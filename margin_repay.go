@@ -0,0 +1,97 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodMarginRepay = "private/margin/repay"
+)
+
+type (
+	// MarginRepayRequest is the request params sent for the private/margin/repay API.
+	MarginRepayRequest struct {
+		// Currency is the currency symbol to repay (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// Amount is the amount to repay.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// MarginRepayResponse is the base response returned from the private/margin/repay API.
+	MarginRepayResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// MarginRepay repays margin loans of a particular currency, along with their accrued interest.
+//
+// The Exchange may only accept the full repayment of all margin loans (see errors.ErrMGInvalidRepayAmount).
+//
+// Method: private/margin/repay
+func (c *Client) MarginRepay(ctx context.Context, req MarginRepayRequest) error {
+	if req.Currency == "" {
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	}
+	if !req.Amount.IsPositive() {
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["currency"] = req.Currency
+	params["amount"] = req.Amount.String()
+
+	var marginRepayResponse MarginRepayResponse
+	err := c.retry(ctx, false, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodMarginRepay,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodMarginRepay,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		marginRepayResponse = MarginRepayResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodMarginRepay, &marginRepayResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, marginRepayResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodMarginTransfer = "private/margin/transfer"
+
+	MarginAccountSpot   MarginAccountType = "SPOT"
+	MarginAccountMargin MarginAccountType = "MARGIN"
+)
+
+type (
+	// MarginAccountType is the account which funds are transferred from/to (SPOT/MARGIN).
+	MarginAccountType string
+
+	// MarginTransferRequest is the request params sent for the private/margin/transfer API.
+	MarginTransferRequest struct {
+		// Currency is the currency symbol to transfer (e.g. BTC or CRO).
+		Currency string `json:"currency"`
+		// From is the account the funds are transferred from.
+		From MarginAccountType `json:"from"`
+		// To is the account the funds are transferred to.
+		To MarginAccountType `json:"to"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// MarginTransferResponse is the base response returned from the private/margin/transfer API.
+	MarginTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// MarginTransfer transfers funds between the spot & margin accounts.
+//
+// Funds cannot be transferred out of the margin account while it holds an active loan.
+//
+// Method: private/margin/transfer
+func (c *Client) MarginTransfer(ctx context.Context, req MarginTransferRequest) error {
+	if req.Currency == "" {
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	}
+	if req.From != MarginAccountSpot && req.From != MarginAccountMargin {
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "must be SPOT or MARGIN"}
+	}
+	if req.To != MarginAccountSpot && req.To != MarginAccountMargin {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "must be SPOT or MARGIN"}
+	}
+	if req.From == req.To {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be the same as req.From"}
+	}
+	if !req.Amount.IsPositive() {
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["currency"] = req.Currency
+	params["from"] = req.From
+	params["to"] = req.To
+	params["amount"] = req.Amount.String()
+
+	var marginTransferResponse MarginTransferResponse
+	err := c.retry(ctx, false, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodMarginTransfer,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodMarginTransfer,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		marginTransferResponse = MarginTransferResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodMarginTransfer, &marginTransferResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, marginTransferResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
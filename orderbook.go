@@ -0,0 +1,469 @@
+package cdcexchange
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/time"
+)
+
+// maxPendingBookUpdates is the number of delta updates buffered while a book is re-snapshotted,
+// the oldest updates are dropped beyond it (leaving a gap which is re-snapshotted again).
+const maxPendingBookUpdates = 1024
+
+// errBookClosed is returned from NewOrderBook when the book stops being maintained before its first snapshot.
+var errBookClosed = goerrors.New("order book closed before its first snapshot")
+
+type (
+	// OrderBook is a locally maintained order book for a single instrument.
+	//
+	// It is initialised from a public/get-book snapshot and kept up to date with the
+	// book.{instrument_name}.{depth} channel. Delta updates are validated against their
+	// sequence numbers and the book is re-snapshotted whenever a gap is detected
+	// or the market websocket is resynced.
+	//
+	// Snapshots are fetched without blocking the update loop, deltas received meanwhile are buffered
+	// and applied on top of the snapshot. A public/get-book snapshot without a sequence number
+	// cannot have deltas applied, so deltas are dropped until the channel publishes a full snapshot.
+	//
+	// This includes the re-snapshot after a gap: if public/get-book returns no sequence number, the book
+	// keeps that snapshot but is not in sync (Err is nil, Sequence is 0) and does not apply deltas again
+	// until the channel publishes a full snapshot.
+	//
+	// All methods are safe for concurrent use.
+	OrderBook struct {
+		client     *Client
+		instrument string
+		depth      int
+
+		// snapshot holds the current *BookSnapshot, it is replaced (never modified) on every update.
+		snapshot atomic.Value
+		done     chan struct{}
+
+		// resyncs receives the snapshots fetched off the update loop.
+		resyncs chan bookResync
+		// ready receives the result of the first snapshot, it is nil once sent.
+		ready chan error
+
+		mu  sync.Mutex
+		err error
+
+		// the following are only accessed from the update loop.
+
+		// synced is false when deltas cannot be applied to the current snapshot.
+		synced bool
+		// resyncing is true while a snapshot is being fetched, deltas are buffered in pending meanwhile.
+		resyncing bool
+		pending   []BookData
+		// generation identifies the latest snapshot fetch, older fetches are discarded.
+		generation uint64
+	}
+
+	// bookResync is the result of a public/get-book snapshot fetch.
+	bookResync struct {
+		generation uint64
+		data       BookData
+		err        error
+	}
+
+	// BookSnapshot is an immutable copy of an OrderBook at a point in time.
+	BookSnapshot struct {
+		// InstrumentName is the instrument of the book (e.g. BTC_USDT).
+		InstrumentName string
+		// Bids are the bids, best (highest price) first.
+		Bids []PriceLevel
+		// Asks are the asks, best (lowest price) first.
+		Asks []PriceLevel
+		// Sequence is the sequence number of the last update applied to the book.
+		Sequence int64
+		// Timestamp is the timestamp of the last update applied to the book.
+		Timestamp time.Time
+	}
+)
+
+// NewOrderBook creates an OrderBook for a particular instrument and depth,
+// which is maintained until ctx is done or the market websocket is closed.
+//
+// depth can be left as 0 to use the Exchange default.
+func (c *Client) NewOrderBook(ctx context.Context, instrument string, depth int) (*OrderBook, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+	if depth < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "depth", Reason: "cannot be less than 0"}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	ready := make(chan error, 1)
+
+	o := &OrderBook{
+		client:     c,
+		instrument: instrument,
+		depth:      depth,
+		done:       make(chan struct{}),
+		resyncs:    make(chan bookResync),
+		ready:      ready,
+	}
+
+	events := c.SubscribeWebsocketEvents(ctx)
+
+	updates, err := c.SubscribeBook(ctx, instrument, depth)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// updates are buffered by the update loop while the initial snapshot is fetched.
+	o.resync(ctx)
+
+	go func() {
+		defer cancel()
+		o.run(ctx, updates, events)
+	}()
+
+	if err := <-ready; err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Snapshot returns the current state of the book.
+//
+// The returned snapshot is never modified, so it can be shared between goroutines without copying.
+func (o *OrderBook) Snapshot() *BookSnapshot {
+	return o.snapshot.Load().(*BookSnapshot)
+}
+
+// BestBid returns the highest bid, false is returned if there are no bids.
+func (o *OrderBook) BestBid() (PriceLevel, bool) {
+	return o.Snapshot().BestBid()
+}
+
+// BestAsk returns the lowest ask, false is returned if there are no asks.
+func (o *OrderBook) BestAsk() (PriceLevel, bool) {
+	return o.Snapshot().BestAsk()
+}
+
+// DepthAtPrice returns the quantity resting at price on one side of the book (BUY for bids, SELL for asks).
+func (o *OrderBook) DepthAtPrice(side OrderSide, price decimal.Decimal) decimal.Decimal {
+	return o.Snapshot().DepthAtPrice(side, price)
+}
+
+// VWAP returns the volume weighted average price of filling quantity against the book.
+//
+// BUY orders are filled against the asks, SELL orders are filled against the bids.
+func (o *OrderBook) VWAP(side OrderSide, quantity decimal.Decimal) (decimal.Decimal, error) {
+	return o.Snapshot().VWAP(side, quantity)
+}
+
+// Done is closed once the book is no longer being maintained.
+func (o *OrderBook) Done() <-chan struct{} {
+	return o.done
+}
+
+// Err returns the reason the last re-snapshot failed, nil is returned if the book is in sync.
+//
+// Updates are not applied while the book is out of sync, re-snapshotting is retried on the next update.
+func (o *OrderBook) Err() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.err
+}
+
+func (o *OrderBook) run(ctx context.Context, updates <-chan BookData, events <-chan StreamEvent) {
+	defer func() {
+		o.signal(errBookClosed)
+		close(o.done)
+	}()
+
+	for {
+		select {
+		case data, ok := <-updates:
+			if !ok {
+				return
+			}
+			o.apply(ctx, data)
+		case res := <-o.resyncs:
+			o.resynced(ctx, res)
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.Type == StreamEventResynced && event.Stream == StreamMarket {
+				o.resync(ctx)
+			}
+		}
+	}
+}
+
+func (o *OrderBook) apply(ctx context.Context, data BookData) {
+	if data.PreviousUpdateSequence == 0 {
+		// the channel publishes full snapshots unless it is sending deltas,
+		// superseding any snapshot being fetched along with the deltas buffered for it.
+		o.generation++
+		o.resyncing = false
+		o.pending = nil
+
+		o.store(o.newSnapshot(data))
+		return
+	}
+
+	if o.resyncing {
+		if len(o.pending) == maxPendingBookUpdates {
+			o.pending = o.pending[1:]
+		}
+		o.pending = append(o.pending, data)
+		return
+	}
+
+	current := o.Snapshot()
+	switch {
+	case !o.synced && o.Err() == nil:
+		// the snapshot has no sequence number, deltas are dropped until the channel publishes a full snapshot.
+		return
+	case !o.synced:
+		// the last snapshot failed, so it is retried.
+		o.resync(ctx)
+		o.apply(ctx, data)
+		return
+	case data.UpdateSequence <= current.Sequence:
+		// already included in the current snapshot.
+		return
+	case data.PreviousUpdateSequence != current.Sequence:
+		// an update has been missed.
+		o.resync(ctx)
+		o.apply(ctx, data)
+		return
+	}
+
+	o.store(current.apply(data, o.depth))
+}
+
+// resync fetches a public/get-book snapshot without blocking the update loop, the result is received by run.
+// It is a no-op if a snapshot is already being fetched.
+func (o *OrderBook) resync(ctx context.Context) {
+	if o.resyncing {
+		return
+	}
+
+	o.resyncing = true
+	o.generation++
+
+	generation := o.generation
+	go func() {
+		res := bookResync{generation: generation}
+
+		book, err := o.client.GetBook(ctx, o.instrument, o.depth)
+		switch {
+		case err != nil:
+			res.err = fmt.Errorf("failed to get book snapshot: %w", err)
+		case len(book.Data) == 0:
+			res.err = fmt.Errorf("failed to get book snapshot: no data returned for %s", o.instrument)
+		default:
+			res.data = book.Data[0]
+		}
+
+		select {
+		case o.resyncs <- res:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// resynced replaces the book with a fetched snapshot, applying the deltas buffered while it was fetched.
+func (o *OrderBook) resynced(ctx context.Context, res bookResync) {
+	if res.generation != o.generation {
+		// superseded by a snapshot published on the channel.
+		return
+	}
+
+	pending := o.pending
+	o.resyncing = false
+	o.pending = nil
+
+	if res.err != nil {
+		o.fail(res.err)
+		return
+	}
+
+	o.store(o.newSnapshot(res.data))
+
+	for _, data := range pending {
+		o.apply(ctx, data)
+	}
+}
+
+func (o *OrderBook) store(snapshot *BookSnapshot) {
+	o.snapshot.Store(snapshot)
+	// deltas can only be validated against a snapshot with a sequence number.
+	o.synced = snapshot.Sequence != 0
+
+	o.mu.Lock()
+	o.err = nil
+	o.mu.Unlock()
+
+	o.signal(nil)
+}
+
+func (o *OrderBook) fail(err error) {
+	o.synced = false
+
+	// the previous snapshot is kept so that readers always have a book.
+	o.mu.Lock()
+	o.err = err
+	o.mu.Unlock()
+
+	o.signal(err)
+}
+
+// signal sends the result of the first snapshot to NewOrderBook.
+func (o *OrderBook) signal(err error) {
+	if o.ready != nil {
+		o.ready <- err
+		o.ready = nil
+	}
+}
+
+func (o *OrderBook) newSnapshot(data BookData) *BookSnapshot {
+	bids := append([]PriceLevel(nil), data.Bids...)
+	asks := append([]PriceLevel(nil), data.Asks...)
+
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	return &BookSnapshot{
+		InstrumentName: o.instrument,
+		Bids:           truncateLevels(bids, o.depth),
+		Asks:           truncateLevels(asks, o.depth),
+		Sequence:       data.UpdateSequence,
+		Timestamp:      data.Timestamp,
+	}
+}
+
+// BestBid returns the highest bid, false is returned if there are no bids.
+func (s *BookSnapshot) BestBid() (PriceLevel, bool) {
+	if len(s.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+
+	return s.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, false is returned if there are no asks.
+func (s *BookSnapshot) BestAsk() (PriceLevel, bool) {
+	if len(s.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+
+	return s.Asks[0], true
+}
+
+// DepthAtPrice returns the quantity resting at price on one side of the book (BUY for bids, SELL for asks).
+func (s *BookSnapshot) DepthAtPrice(side OrderSide, price decimal.Decimal) decimal.Decimal {
+	levels, better := s.Asks, lessPrice
+	if side == OrderSideBuy {
+		levels, better = s.Bids, greaterPrice
+	}
+
+	if i := searchLevels(levels, price, better); i < len(levels) && levels[i].Price.Equal(price) {
+		return levels[i].Quantity
+	}
+
+	return decimal.Zero
+}
+
+// VWAP returns the volume weighted average price of filling quantity against the book.
+//
+// BUY orders are filled against the asks, SELL orders are filled against the bids.
+func (s *BookSnapshot) VWAP(side OrderSide, quantity decimal.Decimal) (decimal.Decimal, error) {
+	if !quantity.IsPositive() {
+		return decimal.Zero, errors.InvalidParameterError{Parameter: "quantity", Reason: "must be greater than 0"}
+	}
+
+	levels := s.Bids
+	if side == OrderSideBuy {
+		levels = s.Asks
+	}
+
+	var (
+		notional  decimal.Decimal
+		remaining = quantity
+	)
+	for _, level := range levels {
+		filled := decimal.Min(level.Quantity, remaining)
+
+		notional = notional.Add(filled.Mul(level.Price))
+		remaining = remaining.Sub(filled)
+
+		if remaining.IsZero() {
+			return notional.Div(quantity), nil
+		}
+	}
+
+	return decimal.Zero, errors.ErrInsufficientBookDepth
+}
+
+// apply returns a copy of the snapshot with the delta in data applied.
+func (s *BookSnapshot) apply(data BookData, depth int) *BookSnapshot {
+	return &BookSnapshot{
+		InstrumentName: s.InstrumentName,
+		Bids:           truncateLevels(mergeLevels(s.Bids, data.Bids, greaterPrice), depth),
+		Asks:           truncateLevels(mergeLevels(s.Asks, data.Asks, lessPrice), depth),
+		Sequence:       data.UpdateSequence,
+		Timestamp:      data.Timestamp,
+	}
+}
+
+// mergeLevels returns a copy of levels with updates applied, levels with a quantity of 0 are removed.
+func mergeLevels(levels []PriceLevel, updates []PriceLevel, better func(a, b decimal.Decimal) bool) []PriceLevel {
+	merged := append(make([]PriceLevel, 0, len(levels)+len(updates)), levels...)
+
+	for _, update := range updates {
+		i := searchLevels(merged, update.Price, better)
+		exists := i < len(merged) && merged[i].Price.Equal(update.Price)
+
+		switch {
+		case update.Quantity.IsZero() && exists:
+			merged = append(merged[:i], merged[i+1:]...)
+		case update.Quantity.IsZero():
+		case exists:
+			merged[i] = update
+		default:
+			merged = append(merged, PriceLevel{})
+			copy(merged[i+1:], merged[i:])
+			merged[i] = update
+		}
+	}
+
+	return merged
+}
+
+// searchLevels returns the index of price in levels, or the index it would be inserted at.
+func searchLevels(levels []PriceLevel, price decimal.Decimal, better func(a, b decimal.Decimal) bool) int {
+	return sort.Search(len(levels), func(i int) bool {
+		return !better(levels[i].Price, price)
+	})
+}
+
+func truncateLevels(levels []PriceLevel, depth int) []PriceLevel {
+	if depth > 0 && len(levels) > depth {
+		return levels[:depth]
+	}
+
+	return levels
+}
+
+func lessPrice(a, b decimal.Decimal) bool    { return a.LessThan(b) }
+func greaterPrice(a, b decimal.Decimal) bool { return a.GreaterThan(b) }
@@ -0,0 +1,511 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+func priceLevel(price string, quantity string, orderCount int) cdcexchange.PriceLevel {
+	return cdcexchange.PriceLevel{
+		Price:      decimal.RequireFromString(price),
+		Quantity:   decimal.RequireFromString(quantity),
+		OrderCount: orderCount,
+	}
+}
+
+func TestClient_NewOrderBook_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		instrument  string
+		depth       int
+		expectedErr error
+	}{
+		{
+			name:        "returns error when instrument is empty",
+			depth:       10,
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when depth is less than 0",
+			instrument:  "BTC_USDT",
+			depth:       -1,
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "depth", Reason: "cannot be less than 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key")
+			require.NoError(t, err)
+
+			book, err := client.NewOrderBook(context.Background(), tt.instrument, tt.depth)
+			require.Error(t, err)
+
+			assert.Nil(t, book)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_NewOrderBook_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		instrument = "BTC_USDT"
+		depth      = 10
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClockAt(now)
+		snapshots   int32
+		updates     = make(chan string)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetBook)
+		assert.Equal(t, instrument, r.URL.Query().Get("instrument_name"))
+		assert.Equal(t, fmt.Sprintf("%d", depth), r.URL.Query().Get("depth"))
+
+		data := fmt.Sprintf(`{"bids":[["100","1","1"],["99","2","1"]],"asks":[["101","1","1"],["102","3","2"]],"t":%d,"u":10}`, now.UnixMilli())
+		if atomic.AddInt32(&snapshots, 1) > 1 {
+			data = fmt.Sprintf(`{"bids":[["98","5","3"]],"asks":[["103","4","2"]],"t":%d,"u":30}`, now.UnixMilli())
+		}
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":%d,"data":[%s]}}`, instrument, depth, data)))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		for data := range updates {
+			publish(t, conn, "book.BTC_USDT.10", data)
+		}
+	})
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	book, err := client.NewOrderBook(ctx, instrument, depth)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), book.Snapshot().Sequence)
+
+	bid, ok := book.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, priceLevel("100", "1", 1), bid)
+
+	// the 100 bid is removed and the 101 ask is replaced.
+	updates <- `[{"bids":[["100","0","0"]],"asks":[["101","2","1"]],"u":11,"pu":10}]`
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 11 }, time.Second, time.Millisecond)
+
+	snapshot := book.Snapshot()
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("99", "2", 1)}, snapshot.Bids)
+	assert.Equal(t, []cdcexchange.PriceLevel{
+		priceLevel("101", "2", 1),
+		priceLevel("102", "3", 2),
+	}, snapshot.Asks)
+
+	// update 12 is missed, so the book is re-snapshotted.
+	updates <- `[{"bids":[["99","1","1"]],"asks":[],"u":13,"pu":12}]`
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 30 }, time.Second, time.Millisecond)
+
+	snapshot = book.Snapshot()
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("98", "5", 3)}, snapshot.Bids)
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("103", "4", 2)}, snapshot.Asks)
+	assert.NoError(t, book.Err())
+
+	close(updates)
+	cancel()
+
+	select {
+	case <-book.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for book to be done")
+	}
+}
+
+func TestClient_NewOrderBook_ResyncDoesNotBlockUpdates(t *testing.T) {
+	const (
+		id = int64(1234)
+
+		instrument = "BTC_USDT"
+		depth      = 10
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		snapshots   int32
+		resyncing   = make(chan struct{})
+		release     = make(chan struct{})
+		messages    = make(chan [2]string)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := fmt.Sprintf(`{"bids":[["100","1","1"]],"asks":[],"t":%d,"u":10}`, now.UnixMilli())
+		if atomic.AddInt32(&snapshots, 1) > 1 {
+			// the re-snapshot is held until every update has been published.
+			close(resyncing)
+			<-release
+			data = fmt.Sprintf(`{"bids":[["100","2","1"]],"asks":[],"t":%d,"u":12}`, now.UnixMilli())
+		}
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":%d,"data":[%s]}}`, instrument, depth, data)))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+	t.Cleanup(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		for i := 0; i < 2; i++ {
+			req := readRequest(t, conn)
+			respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+		}
+
+		for msg := range messages {
+			publish(t, conn, msg[0], msg[1])
+		}
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	book, err := client.NewOrderBook(ctx, instrument, depth)
+	require.NoError(t, err)
+
+	tickers, err := client.SubscribeTickers(ctx, instrument)
+	require.NoError(t, err)
+
+	// update 11 is missed, so the book is re-snapshotted.
+	messages <- [2]string{"book.BTC_USDT.10", `[{"bids":[["99","1","1"]],"asks":[],"u":12,"pu":11}]`}
+
+	select {
+	case <-resyncing:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for re-snapshot")
+	}
+
+	// far more updates than the subscription buffers are published while the re-snapshot is in progress.
+	for u := 13; u < 213; u++ {
+		messages <- [2]string{"book.BTC_USDT.10", fmt.Sprintf(`[{"bids":[],"asks":[["%d","1","1"]],"u":%d,"pu":%d}]`, u, u, u-1)}
+	}
+	messages <- [2]string{"ticker.BTC_USDT", `[{"i":"BTC_USDT"}]`}
+
+	select {
+	case ticker := <-tickers:
+		assert.Equal(t, instrument, ticker.Instrument)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker, the websocket is blocked")
+	}
+
+	close(release)
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 212 }, time.Second, time.Millisecond)
+
+	snapshot := book.Snapshot()
+	assert.Equal(t, []cdcexchange.PriceLevel{priceLevel("100", "2", 1)}, snapshot.Bids)
+	assert.Len(t, snapshot.Asks, depth)
+	assert.Equal(t, priceLevel("13", "1", 1), snapshot.Asks[0])
+	assert.NoError(t, book.Err())
+
+	close(messages)
+}
+
+func TestClient_NewOrderBook_UnsequencedSnapshot(t *testing.T) {
+	const (
+		id = int64(1234)
+
+		instrument = "BTC_USDT"
+		depth      = 10
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		snapshots   int32
+		updates     = make(chan string)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&snapshots, 1)
+
+		// the snapshot has no sequence number.
+		data := fmt.Sprintf(`{"bids":[["100","1","1"]],"asks":[],"t":%d}`, now.UnixMilli())
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":%d,"data":[%s]}}`, instrument, depth, data)))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		for data := range updates {
+			publish(t, conn, "book.BTC_USDT.10", data)
+		}
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	book, err := client.NewOrderBook(ctx, instrument, depth)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), book.Snapshot().Sequence)
+
+	// deltas are dropped until the channel publishes a full snapshot, rather than re-snapshotting.
+	updates <- `[{"bids":[["99","1","1"]],"asks":[],"u":11,"pu":10}]`
+	updates <- `[{"bids":[["98","1","1"]],"asks":[],"u":20}]`
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 20 }, time.Second, time.Millisecond)
+
+	updates <- `[{"bids":[["97","1","1"]],"asks":[],"u":21,"pu":20}]`
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 21 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, []cdcexchange.PriceLevel{
+		priceLevel("98", "1", 1),
+		priceLevel("97", "1", 1),
+	}, book.Snapshot().Bids)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&snapshots))
+	assert.NoError(t, book.Err())
+
+	close(updates)
+}
+
+func TestClient_NewOrderBook_GapWithUnsequencedSnapshot(t *testing.T) {
+	const (
+		id = int64(1234)
+
+		instrument = "BTC_USDT"
+		depth      = 10
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		snapshots   int32
+		updates     = make(chan string)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := fmt.Sprintf(`{"bids":[["100","1","1"]],"asks":[],"t":%d,"u":10}`, now.UnixMilli())
+		if atomic.AddInt32(&snapshots, 1) > 1 {
+			// the re-snapshot has no sequence number.
+			data = fmt.Sprintf(`{"bids":[["100","2","1"]],"asks":[],"t":%d}`, now.UnixMilli())
+		}
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":%d,"data":[%s]}}`, instrument, depth, data)))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		for data := range updates {
+			publish(t, conn, "book.BTC_USDT.10", data)
+		}
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	book, err := client.NewOrderBook(ctx, instrument, depth)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), book.Snapshot().Sequence)
+
+	// update 11 is missed, so the book is re-snapshotted without a sequence number.
+	updates <- `[{"bids":[["99","1","1"]],"asks":[],"u":12,"pu":11}]`
+
+	require.Eventually(t, func() bool {
+		snapshot := book.Snapshot()
+		return snapshot.Sequence == 0 && len(snapshot.Bids) == 1 && snapshot.Bids[0].Quantity.Equal(decimal.NewFromInt(2))
+	}, time.Second, time.Millisecond)
+
+	// the book stays out of sync, so deltas are dropped until the channel publishes a full snapshot.
+	updates <- `[{"bids":[["98","1","1"]],"asks":[],"u":13,"pu":12}]`
+	updates <- `[{"bids":[["97","1","1"]],"asks":[],"u":20}]`
+	updates <- `[{"bids":[["96","1","1"]],"asks":[],"u":21,"pu":20}]`
+
+	require.Eventually(t, func() bool { return book.Snapshot().Sequence == 21 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, []cdcexchange.PriceLevel{
+		priceLevel("97", "1", 1),
+		priceLevel("96", "1", 1),
+	}, book.Snapshot().Bids)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&snapshots))
+	assert.NoError(t, book.Err())
+
+	close(updates)
+}
+
+func TestBookSnapshot(t *testing.T) {
+	snapshot := cdcexchange.BookSnapshot{
+		Bids: []cdcexchange.PriceLevel{
+			priceLevel("100", "1", 1),
+			priceLevel("99", "3", 2),
+		},
+		Asks: []cdcexchange.PriceLevel{
+			priceLevel("101", "2", 1),
+			priceLevel("103", "2", 1),
+		},
+	}
+
+	t.Run("returns best bid & ask", func(t *testing.T) {
+		bid, ok := snapshot.BestBid()
+		require.True(t, ok)
+		assert.Equal(t, snapshot.Bids[0], bid)
+
+		ask, ok := snapshot.BestAsk()
+		require.True(t, ok)
+		assert.Equal(t, snapshot.Asks[0], ask)
+
+		_, ok = (&cdcexchange.BookSnapshot{}).BestBid()
+		assert.False(t, ok)
+	})
+
+	t.Run("returns depth at price", func(t *testing.T) {
+		assert.Equal(t, "3", snapshot.DepthAtPrice(cdcexchange.OrderSideBuy, decimal.NewFromInt(99)).String())
+		assert.Equal(t, "2", snapshot.DepthAtPrice(cdcexchange.OrderSideSell, decimal.NewFromInt(103)).String())
+		assert.Equal(t, "0", snapshot.DepthAtPrice(cdcexchange.OrderSideSell, decimal.NewFromInt(102)).String())
+	})
+
+	tests := []struct {
+		name          string
+		side          cdcexchange.OrderSide
+		quantity      string
+		expectedPrice string
+		expectedErr   error
+	}{
+		{
+			name:          "returns vwap of buy within best ask",
+			side:          cdcexchange.OrderSideBuy,
+			quantity:      "1",
+			expectedPrice: "101",
+		},
+		{
+			name:          "returns vwap of buy across asks",
+			side:          cdcexchange.OrderSideBuy,
+			quantity:      "4",
+			expectedPrice: "102",
+		},
+		{
+			name:          "returns vwap of sell across bids",
+			side:          cdcexchange.OrderSideSell,
+			quantity:      "2",
+			expectedPrice: "99.5",
+		},
+		{
+			name:          "returns error when book is not deep enough",
+			side:          cdcexchange.OrderSideSell,
+			quantity:      "5",
+			expectedPrice: "0",
+			expectedErr:   cdcerrors.ErrInsufficientBookDepth,
+		},
+		{
+			name:          "returns error when quantity is 0",
+			side:          cdcexchange.OrderSideBuy,
+			quantity:      "0",
+			expectedPrice: "0",
+			expectedErr:   cdcerrors.InvalidParameterError{Parameter: "quantity", Reason: "must be greater than 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := snapshot.VWAP(tt.side, decimal.RequireFromString(tt.quantity))
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedPrice, price.String())
+		})
+	}
+}
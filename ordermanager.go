@@ -0,0 +1,575 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	// reconcilePageSize is the page size used to fetch open orders while reconciling.
+	reconcilePageSize = 200
+
+	// OrderEventTracked is published when an order starts being tracked.
+	OrderEventTracked OrderEventType = "TRACKED"
+	// OrderEventUpdated is published when the status or filled quantity of an order changes.
+	OrderEventUpdated OrderEventType = "UPDATED"
+	// OrderEventFill is published when a trade is applied to an order.
+	OrderEventFill OrderEventType = "FILL"
+	// OrderEventUnknown is published when an open order which was not being tracked is found while reconciling.
+	// The order is tracked from then on.
+	OrderEventUnknown OrderEventType = "UNKNOWN"
+	// OrderEventDisappeared is published when an order which was being tracked as open is no longer open while reconciling,
+	// and GetOrderDetail reports it in a terminal status (the event has its final status) or does not find it
+	// (the event has its last tracked state, and the order is no longer tracked).
+	OrderEventDisappeared OrderEventType = "DISAPPEARED"
+)
+
+type (
+	// OrderManagerConfig configures an OrderManager.
+	OrderManagerConfig struct {
+		// InstrumentName restricts reconciliation to the orders of an instrument (e.g. BTC_USDT).
+		// if InstrumentName is omitted, the orders of all instruments are reconciled.
+		InstrumentName string
+		// ReconcileInterval is how often the tracked orders are reconciled against private/get-open-orders.
+		// if ReconcileInterval is 0, orders are only reconciled when Reconcile is called.
+		ReconcileInterval time.Duration
+	}
+
+	// OrderManager tracks the orders placed through it (or passed to Track), keyed by OrderID & ClientOID.
+	//
+	// Orders are kept up to date by applying fills (ApplyTrade) and order updates (ApplyUpdate),
+	// e.g. from the user.trade & user.order channels, and by periodically reconciling against the open orders
+	// on the Exchange. Status transitions are validated, so an order can not leave a terminal status.
+	//
+	// Orders in a terminal status are kept until they are removed with Forget.
+	//
+	// All methods are safe for concurrent use.
+	OrderManager struct {
+		client     *Client
+		instrument string
+		done       chan struct{}
+
+		mu          sync.Mutex
+		orders      map[string]*managedOrder
+		byClientOID map[string]string
+		err         error
+
+		subsMu sync.Mutex
+		subs   map[chan OrderEvent]struct{}
+	}
+
+	// TrackedOrder is a copy of the state of a tracked order.
+	TrackedOrder struct {
+		// Order is the current state of the order.
+		Order Order
+		// Trades are the trades applied to the order, oldest first.
+		Trades []Trade
+	}
+
+	// OrderEventType is the type of order event (TRACKED, UPDATED, FILL, UNKNOWN or DISAPPEARED).
+	OrderEventType string
+
+	// OrderEvent is a change to a tracked order.
+	OrderEvent struct {
+		// Type is the type of event.
+		Type OrderEventType
+		// Order is the state of the order after the event.
+		Order Order
+		// Trade is the trade which was applied (FILL only).
+		Trade *Trade
+		// Time is the time of the event.
+		Time time.Time
+	}
+
+	// OrderTransitionError is returned when an update would move an order out of a terminal status.
+	OrderTransitionError struct {
+		// OrderID is the unique identifier for the order.
+		OrderID string
+		// From is the current status of the order.
+		From OrderStatus
+		// To is the status of the rejected update.
+		To OrderStatus
+	}
+
+	managedOrder struct {
+		order     Order
+		trades    []Trade
+		tradeIDs  map[string]struct{}
+		trackedAt time.Time
+
+		// reported is the cumulative quantity & value last reported by the Exchange,
+		// filled is the sum of the trades applied, the greater of the two is the order's filled quantity.
+		reportedQuantity, reportedValue decimal.Decimal
+		filledQuantity, filledValue     decimal.Decimal
+	}
+)
+
+// Error returns the error message.
+func (e OrderTransitionError) Error() string {
+	return fmt.Sprintf("order %s can not move from %s to %s: %v", e.OrderID, e.From, e.To, errors.ErrInvalidOrderTransition)
+}
+
+// Unwrap returns errors.ErrInvalidOrderTransition.
+func (e OrderTransitionError) Unwrap() error {
+	return errors.ErrInvalidOrderTransition
+}
+
+// NewOrderManager creates an OrderManager, which reconciles its orders every cfg.ReconcileInterval until ctx is done.
+func (c *Client) NewOrderManager(ctx context.Context, cfg OrderManagerConfig) (*OrderManager, error) {
+	if cfg.ReconcileInterval < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "cfg.ReconcileInterval", Reason: "cannot be less than 0"}
+	}
+
+	m := &OrderManager{
+		client:      c,
+		instrument:  cfg.InstrumentName,
+		done:        make(chan struct{}),
+		orders:      make(map[string]*managedOrder),
+		byClientOID: make(map[string]string),
+		subs:        make(map[chan OrderEvent]struct{}),
+	}
+
+	go m.run(ctx, cfg.ReconcileInterval)
+
+	return m, nil
+}
+
+// Events subscribes to changes of the tracked orders.
+//
+// Events are dropped if the returned channel is full.
+// The returned channel is closed once ctx is done.
+func (m *OrderManager) Events(ctx context.Context) <-chan OrderEvent {
+	ch := make(chan OrderEvent, streamBufferSize)
+
+	m.subsMu.Lock()
+	m.subs[ch] = struct{}{}
+	m.subsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.subsMu.Lock()
+		delete(m.subs, ch)
+		m.subsMu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Done is closed once the orders are no longer being periodically reconciled.
+func (m *OrderManager) Done() <-chan struct{} {
+	return m.done
+}
+
+// Err returns the reason the last periodic reconciliation failed, nil is returned if it succeeded.
+func (m *OrderManager) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.err
+}
+
+// CreateOrder creates an order with the Client and tracks it as ACTIVE.
+//
+// Method: private/create-order
+func (m *OrderManager) CreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
+	res, err := m.client.CreateOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Track(Order{
+		Status:         OrderStatusActive,
+		Side:           req.Side,
+		Price:          req.Price,
+		Quantity:       req.Quantity,
+		OrderID:        res.OrderID,
+		ClientOID:      req.ClientOID,
+		CreateTime:     cdctime.Time(m.client.clock.Now()),
+		OrderType:      req.Type,
+		InstrumentName: req.InstrumentName,
+		TimeInForce:    req.TimeInForce,
+		ExecInst:       req.ExecInst,
+		TriggerPrice:   req.TriggerPrice,
+	})
+
+	return res, nil
+}
+
+// Track starts tracking an order which was placed elsewhere (e.g. another OrderManager or the websocket),
+// publishing a TRACKED event.
+//
+// If the order is already tracked, order is applied as an update instead.
+func (m *OrderManager) Track(order Order) {
+	m.track(order, OrderEventTracked)
+}
+
+// ApplyUpdate applies the latest state of an order from the Exchange (e.g. from private/get-order-detail
+// or the user.order channel), publishing an UPDATED event if its status or filled quantity changed.
+//
+// Updates which would move the order out of a terminal status return an OrderTransitionError,
+// and errors.ErrOrderNotTracked is returned if the order is not tracked.
+func (m *OrderManager) ApplyUpdate(order Order) error {
+	m.mu.Lock()
+	o, ok := m.orders[order.OrderID]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("order %s: %w", order.OrderID, errors.ErrOrderNotTracked)
+	}
+
+	changed, err := o.update(order)
+	if err == nil && order.ClientOID != "" {
+		m.byClientOID[order.ClientOID] = order.OrderID
+	}
+	snapshot := o.order
+	m.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if changed {
+		m.publish(OrderEvent{Type: OrderEventUpdated, Order: snapshot})
+	}
+
+	return nil
+}
+
+// ApplyTrade applies a fill to its order, publishing a FILL event.
+// The order is FILLED once the quantity of its trades reaches its quantity.
+//
+// Trades which have already been applied are ignored, and errors.ErrOrderNotTracked is returned if the order is not tracked.
+func (m *OrderManager) ApplyTrade(trade Trade) error {
+	m.mu.Lock()
+	o, ok := m.orders[trade.OrderID]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("order %s: %w", trade.OrderID, errors.ErrOrderNotTracked)
+	}
+
+	applied := o.applyTrade(trade)
+	snapshot := o.order
+	m.mu.Unlock()
+
+	if applied {
+		m.publish(OrderEvent{Type: OrderEventFill, Order: snapshot, Trade: &trade})
+	}
+
+	return nil
+}
+
+// Order returns the tracked order with the given order ID, false is returned if it is not tracked.
+func (m *OrderManager) Order(orderID string) (TrackedOrder, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, ok := m.orders[orderID]
+	if !ok {
+		return TrackedOrder{}, false
+	}
+
+	return o.snapshot(), true
+}
+
+// OrderByClientOID returns the tracked order with the given Client order ID, false is returned if it is not tracked.
+func (m *OrderManager) OrderByClientOID(clientOID string) (TrackedOrder, bool) {
+	m.mu.Lock()
+	orderID, ok := m.byClientOID[clientOID]
+	m.mu.Unlock()
+
+	if !ok {
+		return TrackedOrder{}, false
+	}
+
+	return m.Order(orderID)
+}
+
+// Forget stops tracking the order with the given order ID (e.g. once it is in a terminal status and no longer needed),
+// false is returned if it is not tracked.
+func (m *OrderManager) Forget(orderID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.forget(orderID)
+}
+
+// OpenOrders returns the tracked orders which are not in a terminal status.
+func (m *OrderManager) OpenOrders() []TrackedOrder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var orders []TrackedOrder
+	for _, o := range m.orders {
+		if !o.order.Status.IsTerminal() {
+			orders = append(orders, o.snapshot())
+		}
+	}
+
+	return orders
+}
+
+// Reconcile compares the tracked orders with the open orders on the Exchange.
+//
+// Open orders which are not tracked are tracked from then on (publishing an UNKNOWN event),
+// tracked orders are updated with their latest state, and open orders which are tracked but no longer open
+// are resolved with GetOrderDetail. A DISAPPEARED event is only published once the order detail is in a terminal status
+// or the order is not found, otherwise the order is updated and checked again on the next reconciliation
+// (e.g. if it was not listed due to the open orders changing while paging).
+// Orders which fail to reconcile are retried on the next reconciliation, the first error being returned.
+//
+// Methods: private/get-open-orders, private/get-order-detail
+func (m *OrderManager) Reconcile(ctx context.Context) error {
+	// orders tracked after the open orders are fetched may not be included in them.
+	start := m.client.clock.Now()
+
+	open, err := m.client.IterateOpenOrders(GetOpenOrdersRequest{
+		InstrumentName: m.instrument,
+		PageSize:       reconcilePageSize,
+	}).All(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	var firstErr error
+	seen := make(map[string]struct{}, len(open))
+	for _, order := range open {
+		seen[order.OrderID] = struct{}{}
+
+		if !m.track(order, OrderEventUnknown) {
+			continue
+		}
+		if err := m.ApplyUpdate(order); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to reconcile order %s: %w", order.OrderID, err)
+		}
+	}
+
+	for _, orderID := range m.missing(seen, start) {
+		detail, err := m.client.GetOrderDetail(ctx, orderID)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to get order detail %s: %w", orderID, err)
+			}
+			continue
+		}
+
+		if err := m.resolve(orderID, detail); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to reconcile order %s: %w", orderID, err)
+		}
+	}
+
+	return firstErr
+}
+
+// track starts tracking order, publishing eventType.
+// true is returned if the order was already tracked, in which case nothing is changed.
+func (m *OrderManager) track(order Order, eventType OrderEventType) bool {
+	m.mu.Lock()
+	if _, ok := m.orders[order.OrderID]; ok {
+		m.mu.Unlock()
+		if eventType == OrderEventTracked {
+			_ = m.ApplyUpdate(order)
+		}
+		return true
+	}
+
+	o := &managedOrder{
+		order:            order,
+		tradeIDs:         make(map[string]struct{}),
+		trackedAt:        m.client.clock.Now(),
+		reportedQuantity: order.CumulativeQuantity,
+		reportedValue:    order.CumulativeValue,
+	}
+	m.orders[order.OrderID] = o
+	if order.ClientOID != "" {
+		m.byClientOID[order.ClientOID] = order.OrderID
+	}
+	m.mu.Unlock()
+
+	m.publish(OrderEvent{Type: eventType, Order: order})
+	return false
+}
+
+// missing returns the IDs of the open orders tracked before start which are not in seen.
+func (m *OrderManager) missing(seen map[string]struct{}, start time.Time) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var orderIDs []string
+	for orderID, o := range m.orders {
+		if _, ok := seen[orderID]; ok || o.order.Status.IsTerminal() || !o.trackedAt.Before(start) {
+			continue
+		}
+		if m.instrument != "" && o.order.InstrumentName != m.instrument {
+			continue
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	return orderIDs
+}
+
+// resolve applies the detail of an order which is missing from the open orders,
+// publishing a DISAPPEARED event if it is in a terminal status or was not found.
+func (m *OrderManager) resolve(orderID string, detail *GetOrderDetailResult) error {
+	m.mu.Lock()
+	o, ok := m.orders[orderID]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("order %s: %w", orderID, errors.ErrOrderNotTracked)
+	}
+
+	// the order is not found, so it is no longer tracked.
+	if detail.OrderInfo.OrderID == "" {
+		snapshot := o.order
+		m.forget(orderID)
+		m.mu.Unlock()
+
+		m.publish(OrderEvent{Type: OrderEventDisappeared, Order: snapshot})
+		return nil
+	}
+
+	for _, trade := range detail.TradeList {
+		o.applyTrade(trade)
+	}
+	changed, err := o.update(detail.OrderInfo)
+	snapshot := o.order
+	m.mu.Unlock()
+
+	switch {
+	case snapshot.Status.IsTerminal():
+		m.publish(OrderEvent{Type: OrderEventDisappeared, Order: snapshot})
+	case changed && err == nil:
+		m.publish(OrderEvent{Type: OrderEventUpdated, Order: snapshot})
+	}
+
+	return err
+}
+
+// forget removes an order, m.mu must be held.
+func (m *OrderManager) forget(orderID string) bool {
+	o, ok := m.orders[orderID]
+	if !ok {
+		return false
+	}
+
+	delete(m.orders, orderID)
+	if o.order.ClientOID != "" && m.byClientOID[o.order.ClientOID] == orderID {
+		delete(m.byClientOID, o.order.ClientOID)
+	}
+
+	return true
+}
+
+func (m *OrderManager) run(ctx context.Context, interval time.Duration) {
+	defer close(m.done)
+
+	if interval == 0 {
+		<-ctx.Done()
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.client.clock.After(interval):
+		}
+
+		err := m.Reconcile(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		m.mu.Lock()
+		m.err = err
+		m.mu.Unlock()
+	}
+}
+
+func (m *OrderManager) publish(event OrderEvent) {
+	event.Time = m.client.clock.Now()
+
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+
+	for ch := range m.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// update applies the latest state of the order from the Exchange, returning whether its status or filled quantity changed.
+func (o *managedOrder) update(order Order) (bool, error) {
+	current := o.order.Status
+	if current == OrderStatusFilled && order.Status == OrderStatusActive && order.CumulativeQuantity.LessThan(o.order.CumulativeQuantity) {
+		// reported before the trades which filled the order were applied.
+		return false, nil
+	}
+	if current.IsTerminal() && order.Status != current {
+		return false, OrderTransitionError{OrderID: o.order.OrderID, From: current, To: order.Status}
+	}
+	if order.UpdateTime.Time().Before(o.order.UpdateTime.Time()) {
+		// a stale update.
+		return false, nil
+	}
+
+	before := o.order
+	o.order = order
+	if order.CumulativeQuantity.GreaterThan(o.reportedQuantity) {
+		o.reportedQuantity, o.reportedValue = order.CumulativeQuantity, order.CumulativeValue
+	}
+	// the quantities of the trades applied are kept, as the update may not include them yet.
+	o.setFilled()
+
+	return before.Status != o.order.Status || !before.CumulativeQuantity.Equal(o.order.CumulativeQuantity), nil
+}
+
+// applyTrade applies a fill to the order, returning false if it has already been applied.
+func (o *managedOrder) applyTrade(trade Trade) bool {
+	if _, ok := o.tradeIDs[trade.TradeID]; ok {
+		return false
+	}
+	o.tradeIDs[trade.TradeID] = struct{}{}
+	o.trades = append(o.trades, trade)
+
+	o.filledQuantity = o.filledQuantity.Add(trade.TradedQuantity)
+	o.filledValue = o.filledValue.Add(trade.TradedQuantity.Mul(trade.TradedPrice))
+	o.setFilled()
+
+	return true
+}
+
+// setFilled sets the filled quantities of the order to the greater of those reported by the Exchange & those of its trades,
+// moving an ACTIVE order to FILLED once its quantity has been filled.
+func (o *managedOrder) setFilled() {
+	quantity, value := o.reportedQuantity, o.reportedValue
+	if o.filledQuantity.GreaterThan(quantity) {
+		quantity, value = o.filledQuantity, o.filledValue
+	}
+
+	o.order.CumulativeQuantity, o.order.CumulativeValue = quantity, value
+	if quantity.IsPositive() {
+		o.order.AvgPrice = value.Div(quantity)
+	}
+
+	if o.order.Status == OrderStatusActive && o.order.Quantity.IsPositive() && !quantity.LessThan(o.order.Quantity) {
+		o.order.Status = OrderStatusFilled
+	}
+}
+
+func (o *managedOrder) snapshot() TrackedOrder {
+	return TrackedOrder{
+		Order:  o.order,
+		Trades: append([]Trade(nil), o.trades...),
+	}
+}
@@ -0,0 +1,453 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// orderManagerServer serves the orders in openOrders from private/get-open-orders (on the first page),
+// details[order_id] from private/get-order-detail and creates orders with incrementing IDs from private/create-order.
+func orderManagerServer(t *testing.T, openOrders func() string, details map[string]string) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		created int
+	)
+
+	s, _ := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		var result string
+		switch req.Method {
+		case cdcexchange.MethodCreateOrder:
+			mu.Lock()
+			created++
+			result = fmt.Sprintf(`{"order_id":"%d","client_oid":"%v"}`, created, req.Params["client_oid"])
+			mu.Unlock()
+		case cdcexchange.MethodGetOpenOrders:
+			orders := ""
+			if req.Params["page"].(float64) == 0 {
+				orders = openOrders()
+			}
+			result = fmt.Sprintf(`{"count":0,"order_list":[%s]}`, orders)
+		case cdcexchange.MethodGetOrderDetail:
+			var ok bool
+			result, ok = details[req.Params["order_id"].(string)]
+			require.True(t, ok, "unexpected order: %v", req.Params["order_id"])
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+		}
+
+		return okResponse(result)
+	})
+
+	return s
+}
+
+// nextOrderEvent returns the next event from events, failing the test if none is received.
+func nextOrderEvent(t *testing.T, events <-chan cdcexchange.OrderEvent) cdcexchange.OrderEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order event")
+		return cdcexchange.OrderEvent{}
+	}
+}
+
+func TestClient_NewOrderManager_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key")
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(context.Background(), cdcexchange.OrderManagerConfig{ReconcileInterval: -1})
+	require.Error(t, err)
+
+	assert.Nil(t, m)
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "cfg.ReconcileInterval", Reason: "cannot be less than 0"}, err)
+}
+
+func TestOrderManager_Orders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := orderManagerServer(t, func() string { return "" }, nil)
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(ctx, cdcexchange.OrderManagerConfig{})
+	require.NoError(t, err)
+	events := m.Events(ctx)
+
+	res, err := m.CreateOrder(ctx, cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          decimal.NewFromInt(100),
+		Quantity:       decimal.NewFromInt(2),
+		ClientOID:      "some client oid",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1", res.OrderID)
+
+	event := nextOrderEvent(t, events)
+	assert.Equal(t, cdcexchange.OrderEventTracked, event.Type)
+	assert.Equal(t, cdcexchange.OrderStatusActive, event.Order.Status)
+
+	order, ok := m.OrderByClientOID("some client oid")
+	require.True(t, ok)
+	assert.Equal(t, "1", order.Order.OrderID)
+	assert.Equal(t, "BTC_USDT", order.Order.InstrumentName)
+
+	t.Run("applies fills until the order is filled", func(t *testing.T) {
+		trade := cdcexchange.Trade{
+			TradeID:        "a",
+			OrderID:        "1",
+			TradedPrice:    decimal.NewFromInt(100),
+			TradedQuantity: decimal.NewFromInt(1),
+		}
+		require.NoError(t, m.ApplyTrade(trade))
+
+		event := nextOrderEvent(t, events)
+		assert.Equal(t, cdcexchange.OrderEventFill, event.Type)
+		assert.Equal(t, "a", event.Trade.TradeID)
+		assert.Equal(t, cdcexchange.OrderStatusActive, event.Order.Status)
+		assert.Equal(t, "1", event.Order.CumulativeQuantity.String())
+
+		// trades which have already been applied are ignored.
+		require.NoError(t, m.ApplyTrade(trade))
+
+		require.NoError(t, m.ApplyTrade(cdcexchange.Trade{
+			TradeID:        "b",
+			OrderID:        "1",
+			TradedPrice:    decimal.NewFromInt(98),
+			TradedQuantity: decimal.NewFromInt(1),
+		}))
+
+		event = nextOrderEvent(t, events)
+		assert.Equal(t, cdcexchange.OrderEventFill, event.Type)
+		assert.Equal(t, "b", event.Trade.TradeID)
+		assert.Equal(t, cdcexchange.OrderStatusFilled, event.Order.Status)
+
+		order, ok := m.Order("1")
+		require.True(t, ok)
+		assert.Equal(t, "2", order.Order.CumulativeQuantity.String())
+		assert.Equal(t, "198", order.Order.CumulativeValue.String())
+		assert.Equal(t, "99", order.Order.AvgPrice.String())
+		assert.Len(t, order.Trades, 2)
+		assert.Empty(t, m.OpenOrders())
+	})
+
+	t.Run("ignores stale updates & rejects updates out of a terminal status", func(t *testing.T) {
+		// reported before the fills were applied.
+		require.NoError(t, m.ApplyUpdate(cdcexchange.Order{
+			OrderID:            "1",
+			Status:             cdcexchange.OrderStatusActive,
+			Quantity:           decimal.NewFromInt(2),
+			CumulativeQuantity: decimal.NewFromInt(1),
+		}))
+
+		err := m.ApplyUpdate(cdcexchange.Order{
+			OrderID:            "1",
+			Status:             cdcexchange.OrderStatusCancelled,
+			Quantity:           decimal.NewFromInt(2),
+			CumulativeQuantity: decimal.NewFromInt(2),
+		})
+		require.Error(t, err)
+
+		var transitionErr cdcexchange.OrderTransitionError
+		require.True(t, errors.As(err, &transitionErr))
+		assert.Equal(t, cdcexchange.OrderTransitionError{
+			OrderID: "1",
+			From:    cdcexchange.OrderStatusFilled,
+			To:      cdcexchange.OrderStatusCancelled,
+		}, transitionErr)
+		assert.True(t, errors.Is(err, cdcerrors.ErrInvalidOrderTransition))
+
+		order, ok := m.Order("1")
+		require.True(t, ok)
+		assert.Equal(t, cdcexchange.OrderStatusFilled, order.Order.Status)
+	})
+
+	t.Run("returns error given untracked order", func(t *testing.T) {
+		err := m.ApplyUpdate(cdcexchange.Order{OrderID: "unknown"})
+		assert.True(t, errors.Is(err, cdcerrors.ErrOrderNotTracked))
+
+		err = m.ApplyTrade(cdcexchange.Trade{OrderID: "unknown"})
+		assert.True(t, errors.Is(err, cdcerrors.ErrOrderNotTracked))
+
+		_, ok := m.Order("unknown")
+		assert.False(t, ok)
+	})
+}
+
+func TestOrderManager_ApplyTrade_Concurrent(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key")
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(context.Background(), cdcexchange.OrderManagerConfig{})
+	require.NoError(t, err)
+
+	const trades = 100
+	m.Track(cdcexchange.Order{
+		OrderID:  "1",
+		Status:   cdcexchange.OrderStatusActive,
+		Quantity: decimal.NewFromInt(trades),
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < trades; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			assert.NoError(t, m.ApplyTrade(cdcexchange.Trade{
+				TradeID:        fmt.Sprint(i),
+				OrderID:        "1",
+				TradedPrice:    decimal.NewFromInt(10),
+				TradedQuantity: decimal.NewFromInt(1),
+			}))
+			m.OpenOrders()
+		}(i)
+	}
+	wg.Wait()
+
+	order, ok := m.Order("1")
+	require.True(t, ok)
+	assert.Equal(t, cdcexchange.OrderStatusFilled, order.Order.Status)
+	assert.Equal(t, "100", order.Order.CumulativeQuantity.String())
+	assert.Len(t, order.Trades, trades)
+}
+
+func TestOrderManager_Reconcile(t *testing.T) {
+	now := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := clockwork.NewFakeClockAt(now)
+	s := orderManagerServer(t,
+		func() string {
+			return `{"order_id":"1","status":"ACTIVE","quantity":"2","cumulative_quantity":"1","cumulative_value":"100","instrument_name":"BTC_USDT"},` +
+				`{"order_id":"3","client_oid":"other client oid","status":"ACTIVE","quantity":"1","instrument_name":"BTC_USDT"}`
+		},
+		map[string]string{
+			"2": `{"trade_list":[{"trade_id":"a","order_id":"2","traded_price":"50","traded_quantity":"0.5"}],` +
+				`"order_info":{"order_id":"2","status":"CANCELED","quantity":"1","cumulative_quantity":"0.5","cumulative_value":"25","instrument_name":"BTC_USDT"}}`,
+		},
+	)
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(ctx, cdcexchange.OrderManagerConfig{
+		InstrumentName:    "BTC_USDT",
+		ReconcileInterval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	for _, orderID := range []string{"1", "2"} {
+		m.Track(cdcexchange.Order{
+			OrderID:        orderID,
+			Status:         cdcexchange.OrderStatusActive,
+			Quantity:       decimal.NewFromInt(2),
+			InstrumentName: "BTC_USDT",
+		})
+	}
+	// orders of other instruments are not reconciled.
+	m.Track(cdcexchange.Order{OrderID: "4", Status: cdcexchange.OrderStatusActive, InstrumentName: "ETH_USDT"})
+
+	events := m.Events(ctx)
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	event := nextOrderEvent(t, events)
+	assert.Equal(t, cdcexchange.OrderEventUpdated, event.Type)
+	assert.Equal(t, "1", event.Order.OrderID)
+	assert.Equal(t, "1", event.Order.CumulativeQuantity.String())
+
+	event = nextOrderEvent(t, events)
+	assert.Equal(t, cdcexchange.OrderEventUnknown, event.Type)
+	assert.Equal(t, "3", event.Order.OrderID)
+
+	event = nextOrderEvent(t, events)
+	assert.Equal(t, cdcexchange.OrderEventDisappeared, event.Type)
+	assert.Equal(t, "2", event.Order.OrderID)
+	assert.Equal(t, cdcexchange.OrderStatusCancelled, event.Order.Status)
+
+	// the next reconciliation is scheduled once the first has completed.
+	clock.BlockUntil(1)
+	require.NoError(t, m.Err())
+
+	order, ok := m.OrderByClientOID("other client oid")
+	require.True(t, ok)
+	assert.Equal(t, "3", order.Order.OrderID)
+
+	order, ok = m.Order("2")
+	require.True(t, ok)
+	assert.Len(t, order.Trades, 1)
+	assert.Equal(t, "0.5", order.Order.CumulativeQuantity.String())
+
+	open := make(map[string]bool)
+	for _, o := range m.OpenOrders() {
+		open[o.Order.OrderID] = true
+	}
+	assert.Equal(t, map[string]bool{"1": true, "3": true, "4": true}, open)
+
+	cancel()
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order manager to stop")
+	}
+}
+
+func TestOrderManager_Reconcile_OrderDetailError(t *testing.T) {
+	now := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := clockwork.NewFakeClockAt(now)
+	s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		switch req.Method {
+		case cdcexchange.MethodGetOpenOrders:
+			return okResponse(`{"count":0,"order_list":[]}`)
+		case cdcexchange.MethodGetOrderDetail:
+			if req.Params["order_id"] == "1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return `{"id":0,"method":"","code":10003}`
+			}
+			return okResponse(`{"trade_list":[],` +
+				`"order_info":{"order_id":"2","status":"CANCELED","quantity":"1","instrument_name":"BTC_USDT"}}`)
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+			return okResponse("{}")
+		}
+	})
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(ctx, cdcexchange.OrderManagerConfig{})
+	require.NoError(t, err)
+
+	for _, orderID := range []string{"1", "2"} {
+		m.Track(cdcexchange.Order{OrderID: orderID, Status: cdcexchange.OrderStatusActive, InstrumentName: "BTC_USDT"})
+	}
+
+	t.Run("skips orders tracked as the open orders are fetched", func(t *testing.T) {
+		require.NoError(t, m.Reconcile(ctx))
+
+		for _, req := range requests() {
+			assert.Equal(t, cdcexchange.MethodGetOpenOrders, req.Method)
+		}
+	})
+
+	t.Run("resolves the other orders after failing to get an order detail", func(t *testing.T) {
+		clock.Advance(time.Second)
+
+		err := m.Reconcile(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+
+		order, ok := m.Order("1")
+		require.True(t, ok)
+		assert.Equal(t, cdcexchange.OrderStatusActive, order.Order.Status)
+
+		order, ok = m.Order("2")
+		require.True(t, ok)
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, order.Order.Status)
+	})
+}
+
+func TestOrderManager_Reconcile_MissingOrders(t *testing.T) {
+	now := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := clockwork.NewFakeClockAt(now)
+	s := orderManagerServer(t,
+		func() string { return "" },
+		map[string]string{
+			"1": `{"trade_list":[],` +
+				`"order_info":{"order_id":"1","client_oid":"client oid","status":"ACTIVE","quantity":"2","cumulative_quantity":"1","cumulative_value":"100","instrument_name":"BTC_USDT"}}`,
+			"2": `{"trade_list":[],"order_info":{}}`,
+		},
+	)
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	m, err := client.NewOrderManager(ctx, cdcexchange.OrderManagerConfig{})
+	require.NoError(t, err)
+
+	m.Track(cdcexchange.Order{
+		OrderID:        "1",
+		ClientOID:      "client oid",
+		Status:         cdcexchange.OrderStatusActive,
+		Quantity:       decimal.NewFromInt(2),
+		InstrumentName: "BTC_USDT",
+	})
+	m.Track(cdcexchange.Order{OrderID: "2", Status: cdcexchange.OrderStatusActive, InstrumentName: "BTC_USDT"})
+
+	events := m.Events(ctx)
+	clock.Advance(time.Second)
+	require.NoError(t, m.Reconcile(ctx))
+
+	received := make(map[string]cdcexchange.OrderEvent)
+	for i := 0; i < 2; i++ {
+		event := nextOrderEvent(t, events)
+		received[event.Order.OrderID] = event
+	}
+
+	t.Run("updates an order which is still active without it disappearing", func(t *testing.T) {
+		assert.Equal(t, cdcexchange.OrderEventUpdated, received["1"].Type)
+		assert.Equal(t, "1", received["1"].Order.CumulativeQuantity.String())
+
+		order, ok := m.Order("1")
+		require.True(t, ok)
+		assert.Equal(t, cdcexchange.OrderStatusActive, order.Order.Status)
+	})
+
+	t.Run("stops tracking an order which is not found", func(t *testing.T) {
+		assert.Equal(t, cdcexchange.OrderEventDisappeared, received["2"].Type)
+		assert.Equal(t, cdcexchange.OrderStatusActive, received["2"].Order.Status)
+
+		_, ok := m.Order("2")
+		assert.False(t, ok)
+	})
+
+	t.Run("forgets an order", func(t *testing.T) {
+		assert.True(t, m.Forget("1"))
+		assert.False(t, m.Forget("1"))
+
+		_, ok := m.Order("1")
+		assert.False(t, ok)
+		_, ok = m.OrderByClientOID("client oid")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,264 @@
+package cdcexchange
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	defaultResolveGracePeriod = 3 * time.Second
+	defaultResolveInterval    = time.Second
+	defaultResolveTimeout     = 10 * time.Second
+	defaultResolveLookback    = time.Minute
+
+	CreateOrderOutcomeCreated CreateOrderOutcome = "CREATED"
+	CreateOrderOutcomeAbsent  CreateOrderOutcome = "ABSENT"
+	CreateOrderOutcomeUnknown CreateOrderOutcome = "UNKNOWN"
+)
+
+type (
+	// ResolvePolicy configures how CreateOrderResolved searches for an order after an ambiguous failure.
+	ResolvePolicy struct {
+		// GracePeriod is how long the orders are searched for the order, as an order may only be listed some time
+		// after it is created.
+		// (Default: 3s)
+		GracePeriod time.Duration
+		// Interval is the delay between searches of the orders during the GracePeriod.
+		// (Default: 1s)
+		Interval time.Duration
+		// Timeout is the time allowed to resolve the order once the ctx of the create-order request is done.
+		// (Default: 10s)
+		Timeout time.Duration
+		// Lookback is the margin either side of the create-order request which the order history is searched over,
+		// allowing for latency & drift of the Exchange's clock.
+		// (Default: 1m)
+		Lookback time.Duration
+	}
+
+	// CreateOrderOutcome is whether an order was created on the Exchange (CREATED, ABSENT or UNKNOWN).
+	CreateOrderOutcome string
+
+	// ResolvedCreateOrderResult is the result of CreateOrderResolved.
+	ResolvedCreateOrderResult struct {
+		// Outcome is whether the order was created:
+		//  - CREATED if the order was acknowledged, or was found by its ClientOID after the request failed
+		//  - ABSENT if the request definitely did not reach the Exchange (its connection could not be established,
+		//    and it was not retried)
+		//  - UNKNOWN if the request failed & the order was not found by its ClientOID within the grace period,
+		//    or could not be looked up (see ResolveErr)
+		//
+		// As orders are created asynchronously, an order which is not found may still be created, so an UNKNOWN order
+		// should not be re-placed (other than with the same ClientOID) unless it is known to be absent by other means.
+		Outcome CreateOrderOutcome
+		// OrderID is the ID of the created order (if CREATED).
+		OrderID string
+		// ClientOID is the Client order ID of the order, generated if it was not provided in the request.
+		ClientOID string
+		// Order is the order which was found by its ClientOID after the request failed (if CREATED).
+		Order *Order
+		// Err is the ambiguous error returned from private/create-order, nil if the order was acknowledged.
+		Err error
+		// ResolveErr is the reason the order could not be looked up (if UNKNOWN), nil if the order was looked up
+		// but not found.
+		ResolveErr error
+	}
+)
+
+// WithOrderResolvePolicy will initialise the Client to search for orders with the given policy after an ambiguous
+// failure to create them (see CreateOrderResolved). Default: every 1s for 3s, with a 10s timeout & a 1m lookback.
+func WithOrderResolvePolicy(policy ResolvePolicy) ClientOption {
+	return func(c *Client) error {
+		policy, err := policy.withDefaults("policy")
+		if err != nil {
+			return err
+		}
+
+		c.resolvePolicy = policy
+		return nil
+	}
+}
+
+// withDefaults validates the policy and fills in any unset windows.
+func (p ResolvePolicy) withDefaults(parameter string) (ResolvePolicy, error) {
+	switch {
+	case p.GracePeriod < 0:
+		return ResolvePolicy{}, errors.InvalidParameterError{Parameter: parameter + ".GracePeriod", Reason: "cannot be less than 0"}
+	case p.Interval < 0:
+		return ResolvePolicy{}, errors.InvalidParameterError{Parameter: parameter + ".Interval", Reason: "cannot be less than 0"}
+	case p.Timeout < 0:
+		return ResolvePolicy{}, errors.InvalidParameterError{Parameter: parameter + ".Timeout", Reason: "cannot be less than 0"}
+	case p.Lookback < 0:
+		return ResolvePolicy{}, errors.InvalidParameterError{Parameter: parameter + ".Lookback", Reason: "cannot be less than 0"}
+	}
+
+	if p.GracePeriod == 0 {
+		p.GracePeriod = defaultResolveGracePeriod
+	}
+	if p.Interval == 0 {
+		p.Interval = defaultResolveInterval
+	}
+	if p.Timeout == 0 {
+		p.Timeout = defaultResolveTimeout
+	}
+	if p.Lookback == 0 {
+		p.Lookback = defaultResolveLookback
+	}
+
+	return p, nil
+}
+
+// CreateOrderResolved creates a new BUY or SELL order on the Exchange (see CreateOrder), resolving whether the order
+// was created if the request fails after it may have been sent (e.g. ctx is done, a transport failure or a 5xx response).
+//
+// If req.ClientOID is empty, a random Client order ID is generated, so that the order can be found afterwards.
+// After an ambiguous failure, the open orders & order history of req.InstrumentName are searched for req.ClientOID
+// every second for a grace period of 3s (on the Client's clock), and the outcome is returned rather than the error
+// (which is kept in Err). If ctx is already done, the orders are searched with a new context which times out after 10s.
+// These windows can be configured WithOrderResolvePolicy.
+//
+// Errors which show the order was rejected (e.g. errors.ErrNegativeBalance or an invalid request) are returned as is.
+//
+// Methods: private/create-order, private/get-open-orders, private/get-order-history
+func (c *Client) CreateOrderResolved(ctx context.Context, req CreateOrderRequest) (*ResolvedCreateOrderResult, error) {
+	if req.ClientOID == "" {
+		clientOID, err := newClientOID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client oid: %w", err)
+		}
+		req.ClientOID = clientOID
+	}
+
+	start := time.UnixMilli(c.nonce())
+
+	res, err := c.CreateOrder(ctx, req)
+	if err == nil {
+		return &ResolvedCreateOrderResult{
+			Outcome:   CreateOrderOutcomeCreated,
+			OrderID:   res.OrderID,
+			ClientOID: req.ClientOID,
+		}, nil
+	}
+	if !ambiguous(err) {
+		return nil, err
+	}
+
+	result := &ResolvedCreateOrderResult{
+		ClientOID: req.ClientOID,
+		Err:       err,
+	}
+	if c.notSent(err) {
+		result.Outcome = CreateOrderOutcomeAbsent
+		return result, nil
+	}
+
+	policy, err := c.resolvePolicy.withDefaults("policy")
+	if err != nil {
+		return nil, err
+	}
+
+	resolveCtx := ctx
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		resolveCtx, cancel = context.WithTimeout(context.Background(), policy.Timeout)
+		defer cancel()
+	}
+
+	var (
+		order    *Order
+		deadline = c.clock.Now().Add(policy.GracePeriod)
+	)
+	err = c.poll(resolveCtx, PollPolicy{MinInterval: policy.Interval, MaxInterval: policy.Interval}, func() (bool, error) {
+		var err error
+		order, err = c.findOrderByClientOID(resolveCtx, req.InstrumentName, req.ClientOID, start, policy.Lookback)
+		if err != nil {
+			return false, err
+		}
+		return order != nil || !c.clock.Now().Before(deadline), nil
+	})
+	switch {
+	case err != nil:
+		result.Outcome = CreateOrderOutcomeUnknown
+		result.ResolveErr = err
+	case order == nil:
+		// the order may still be created, as orders are created asynchronously.
+		result.Outcome = CreateOrderOutcomeUnknown
+	default:
+		result.Outcome = CreateOrderOutcomeCreated
+		result.OrderID = order.OrderID
+		result.Order = order
+	}
+
+	return result, nil
+}
+
+// findOrderByClientOID searches the open orders, then the order history since start (with a margin of lookback either
+// side), for an order of instrumentName with the given clientOID. nil is returned if there is no such order.
+func (c *Client) findOrderByClientOID(ctx context.Context, instrumentName string, clientOID string, start time.Time, lookback time.Duration) (*Order, error) {
+	open := c.IterateOpenOrders(GetOpenOrdersRequest{InstrumentName: instrumentName, PageSize: reconcilePageSize})
+	for open.Next(ctx) {
+		if order := open.Order(); order.ClientOID == clientOID {
+			return &order, nil
+		}
+	}
+	if err := open.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	history := c.IterateOrderHistoryInRange(GetOrderHistoryRequest{
+		InstrumentName: instrumentName,
+		Start:          start.Add(-lookback),
+		End:            time.UnixMilli(c.nonce()).Add(lookback),
+		PageSize:       reconcilePageSize,
+	})
+	for history.Next(ctx) {
+		if order := history.Order(); order.ClientOID == clientOID {
+			return &order, nil
+		}
+	}
+	if err := history.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+
+	return nil, nil
+}
+
+// notSent returns whether a create-order request which failed with err definitely did not reach the Exchange,
+// i.e. its connection could not be established & it was not retried (as an earlier attempt may have been sent).
+func (c *Client) notSent(err error) bool {
+	if c.retryPolicy != nil && c.retryPolicy.MaxAttempts > 1 {
+		return false
+	}
+
+	var opErr *net.OpError
+	return goerrors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// ambiguous returns whether a request which failed with err may still have been processed by the Exchange.
+func ambiguous(err error) bool {
+	if goerrors.Is(err, context.Canceled) || goerrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if goerrors.Is(err, errors.ErrTooManyRequests) || goerrors.Is(err, errors.ErrInvalidNonce) {
+		// the request was rejected before being processed.
+		return false
+	}
+
+	// the same failures as are retried for idempotent requests.
+	return retryable(err, true)
+}
+
+// newClientOID generates a random Client order ID (within the 36 characters allowed by the Exchange).
+func newClientOID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
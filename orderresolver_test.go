@@ -0,0 +1,439 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// resolverServer serves private/create-order with createOrder, and the orders in openOrders & orderHistory
+// from the first page of private/get-open-orders & private/get-order-history.
+//
+// A private/get-open-orders response code can be given to fail the lookup of the order.
+func resolverServer(t *testing.T, createOrder func(w http.ResponseWriter, r *http.Request) string, openOrders string, orderHistory string, openOrdersCode int) (*httptest.Server, func() []api.Request) {
+	return apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		orders := ""
+		switch req.Method {
+		case cdcexchange.MethodCreateOrder:
+			return createOrder(w, r)
+		case cdcexchange.MethodGetOpenOrders:
+			if openOrdersCode != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return fmt.Sprintf(`{"id":0,"method":"","code":%d}`, openOrdersCode)
+			}
+			orders = openOrders
+		case cdcexchange.MethodGetOrderHistory:
+			orders = orderHistory
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+		}
+		if req.Params["page"].(float64) != 0 {
+			orders = ""
+		}
+
+		return okResponse(fmt.Sprintf(`{"order_list":[%s]}`, orders))
+	})
+}
+
+func TestClient_CreateOrderResolved(t *testing.T) {
+	const clientOID = "some client oid"
+	req := cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          decimal.NewFromInt(100),
+		Quantity:       decimal.NewFromInt(1),
+		ClientOID:      clientOID,
+	}
+
+	created := func(w http.ResponseWriter, r *http.Request) string {
+		return okResponse(`{"order_id":"1"}`)
+	}
+	badGateway := func(w http.ResponseWriter, r *http.Request) string {
+		w.WriteHeader(http.StatusBadGateway)
+		return `<html>bad gateway</html>`
+	}
+	rejected := func(w http.ResponseWriter, r *http.Request) string {
+		w.WriteHeader(http.StatusBadRequest)
+		return `{"id":0,"method":"","code":20002}`
+	}
+	order := fmt.Sprintf(`{"order_id":"2","client_oid":"%s","status":"ACTIVE","instrument_name":"BTC_USDT"}`, clientOID)
+	otherOrder := `{"order_id":"3","client_oid":"other client oid","status":"FILLED","instrument_name":"BTC_USDT"}`
+
+	// the orders are searched once a second for the 3s grace period.
+	notFoundMethods := []string{cdcexchange.MethodCreateOrder}
+	for i := 0; i < 4; i++ {
+		notFoundMethods = append(notFoundMethods,
+			cdcexchange.MethodGetOpenOrders,
+			cdcexchange.MethodGetOpenOrders,
+			cdcexchange.MethodGetOrderHistory,
+			cdcexchange.MethodGetOrderHistory,
+		)
+	}
+
+	tests := []struct {
+		name           string
+		req            cdcexchange.CreateOrderRequest
+		createOrder    func(w http.ResponseWriter, r *http.Request) string
+		openOrders     string
+		orderHistory   string
+		openOrdersCode int
+		timeout        time.Duration
+		polls          int
+
+		expectedOutcome cdcexchange.CreateOrderOutcome
+		expectedOrderID string
+		expectedMethods []string
+		expectedErr     error
+		resolveErr      error
+	}{
+		{
+			name:            "returns created order which was acknowledged",
+			req:             req,
+			createOrder:     created,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeCreated,
+			expectedOrderID: "1",
+			expectedMethods: []string{cdcexchange.MethodCreateOrder},
+		},
+		{
+			name:            "returns error when order is rejected",
+			req:             req,
+			createOrder:     rejected,
+			expectedMethods: []string{cdcexchange.MethodCreateOrder},
+			expectedErr:     cdcerrors.ErrNegativeBalance,
+		},
+		{
+			name:            "returns created order found in open orders after ambiguous failure",
+			req:             req,
+			createOrder:     badGateway,
+			openOrders:      otherOrder + "," + order,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeCreated,
+			expectedOrderID: "2",
+			expectedMethods: []string{cdcexchange.MethodCreateOrder, cdcexchange.MethodGetOpenOrders},
+		},
+		{
+			name:            "returns created order found in order history after ambiguous failure",
+			req:             req,
+			createOrder:     badGateway,
+			orderHistory:    otherOrder + "," + order,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeCreated,
+			expectedOrderID: "2",
+			expectedMethods: []string{
+				cdcexchange.MethodCreateOrder,
+				cdcexchange.MethodGetOpenOrders,
+				cdcexchange.MethodGetOrderHistory,
+				cdcexchange.MethodGetOrderHistory,
+			},
+		},
+		{
+			name:            "returns unknown order which was not found within the grace period after ambiguous failure",
+			req:             req,
+			createOrder:     badGateway,
+			openOrders:      otherOrder,
+			orderHistory:    otherOrder,
+			polls:           3,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeUnknown,
+			expectedMethods: notFoundMethods,
+		},
+		{
+			name:            "returns unknown order which could not be looked up after ambiguous failure",
+			req:             req,
+			createOrder:     badGateway,
+			openOrdersCode:  10003,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeUnknown,
+			expectedMethods: []string{cdcexchange.MethodCreateOrder, cdcexchange.MethodGetOpenOrders},
+			resolveErr:      cdcerrors.ErrIllegalIP,
+		},
+		{
+			name: "resolves order once ctx is done",
+			req:  req,
+			createOrder: func(w http.ResponseWriter, r *http.Request) string {
+				<-r.Context().Done()
+				return ""
+			},
+			openOrders:      order,
+			timeout:         50 * time.Millisecond,
+			expectedOutcome: cdcexchange.CreateOrderOutcomeCreated,
+			expectedOrderID: "2",
+			expectedMethods: []string{cdcexchange.MethodCreateOrder, cdcexchange.MethodGetOpenOrders},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s, requests := resolverServer(t, tt.createOrder, tt.openOrders, tt.orderHistory, tt.openOrdersCode)
+
+			clock := clockwork.NewFakeClockAt(time.Now())
+			client, err := cdcexchange.New("api key", "secret key",
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithRateLimit(cdcexchange.MethodGetOpenOrders, cdcexchange.RateLimit{}),
+				cdcexchange.WithRateLimit(cdcexchange.MethodGetOrderHistory, cdcexchange.RateLimit{}),
+			)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			var (
+				res  *cdcexchange.ResolvedCreateOrderResult
+				done = make(chan struct{})
+			)
+			go func() {
+				defer close(done)
+				res, err = client.CreateOrderResolved(ctx, tt.req)
+			}()
+			for i := 0; i < tt.polls; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(time.Second)
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("timed out resolving order")
+			}
+
+			var methods []string
+			for _, r := range requests() {
+				methods = append(methods, r.Method)
+			}
+			assert.Equal(t, tt.expectedMethods, methods)
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.Nil(t, res)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedOutcome, res.Outcome)
+			assert.Equal(t, tt.expectedOrderID, res.OrderID)
+			assert.Equal(t, clientOID, res.ClientOID)
+			if res.Order != nil {
+				assert.Equal(t, clientOID, res.Order.ClientOID)
+			}
+			if len(tt.expectedMethods) > 1 {
+				assert.Error(t, res.Err)
+			} else {
+				assert.NoError(t, res.Err)
+			}
+			if tt.resolveErr != nil {
+				assert.True(t, errors.Is(res.ResolveErr, tt.resolveErr))
+			} else {
+				assert.NoError(t, res.ResolveErr)
+			}
+		})
+	}
+}
+
+func TestWithOrderResolvePolicy_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithOrderResolvePolicy(cdcexchange.ResolvePolicy{GracePeriod: -1}),
+	)
+	require.Error(t, err)
+
+	assert.Empty(t, client)
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "policy.GracePeriod", Reason: "cannot be less than 0"}, err)
+}
+
+func TestClient_CreateOrderResolved_NotSent(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(&http.Client{Transport: roundTripper{err: dialErr}}),
+	)
+	require.NoError(t, err)
+
+	res, err := client.CreateOrderResolved(context.Background(), cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeMarket,
+		Quantity:       decimal.NewFromInt(1),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, cdcexchange.CreateOrderOutcomeAbsent, res.Outcome)
+	assert.True(t, errors.Is(res.Err, dialErr))
+	assert.NoError(t, res.ResolveErr)
+}
+
+func TestClient_CreateOrderResolved_GracePeriod(t *testing.T) {
+	const clientOID = "some client oid"
+	req := cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          decimal.NewFromInt(100),
+		Quantity:       decimal.NewFromInt(1),
+		ClientOID:      clientOID,
+	}
+
+	// newClient creates a Client for a server which fails to create orders, and lists the order as open
+	// from the given search of the orders onwards.
+	newClient := func(t *testing.T, listedFrom int, opts ...cdcexchange.ClientOption) (*cdcexchange.Client, clockwork.FakeClock) {
+		var (
+			mu       sync.Mutex
+			searches int
+		)
+		s, _ := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+			switch req.Method {
+			case cdcexchange.MethodCreateOrder:
+				w.WriteHeader(http.StatusBadGateway)
+				return `<html>bad gateway</html>`
+			case cdcexchange.MethodGetOpenOrders:
+				if req.Params["page"].(float64) != 0 {
+					return okResponse(`{"order_list":[]}`)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				if searches++; searches < listedFrom {
+					return okResponse(`{"order_list":[]}`)
+				}
+				return okResponse(fmt.Sprintf(`{"order_list":[{"order_id":"2","client_oid":"%s","status":"ACTIVE"}]}`, clientOID))
+			case cdcexchange.MethodGetOrderHistory:
+				return okResponse(`{"order_list":[]}`)
+			default:
+				t.Errorf("unexpected method: %s", req.Method)
+				return okResponse("{}")
+			}
+		})
+
+		clock := clockwork.NewFakeClockAt(time.Now())
+		client, err := cdcexchange.New("api key", "secret key", append([]cdcexchange.ClientOption{
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithRateLimit(cdcexchange.MethodGetOrderHistory, cdcexchange.RateLimit{}),
+		}, opts...)...)
+		require.NoError(t, err)
+
+		return client, clock
+	}
+
+	t.Run("returns created order which is listed during the grace period", func(t *testing.T) {
+		client, clock := newClient(t, 2)
+
+		done := make(chan *cdcexchange.ResolvedCreateOrderResult)
+		go func() {
+			res, err := client.CreateOrderResolved(context.Background(), req)
+			assert.NoError(t, err)
+			done <- res
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+
+		select {
+		case res := <-done:
+			require.NotNil(t, res)
+			assert.Equal(t, cdcexchange.CreateOrderOutcomeCreated, res.Outcome)
+			assert.Equal(t, "2", res.OrderID)
+			assert.Error(t, res.Err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out resolving order")
+		}
+	})
+
+	t.Run("searches for the configured grace period", func(t *testing.T) {
+		client, clock := newClient(t, 10, cdcexchange.WithOrderResolvePolicy(cdcexchange.ResolvePolicy{
+			GracePeriod: 10 * time.Second,
+			Interval:    5 * time.Second,
+		}))
+
+		done := make(chan *cdcexchange.ResolvedCreateOrderResult)
+		go func() {
+			res, err := client.CreateOrderResolved(context.Background(), req)
+			assert.NoError(t, err)
+			done <- res
+		}()
+
+		for i := 0; i < 2; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(5 * time.Second)
+		}
+
+		select {
+		case res := <-done:
+			require.NotNil(t, res)
+			assert.Equal(t, cdcexchange.CreateOrderOutcomeUnknown, res.Outcome)
+			assert.NoError(t, res.ResolveErr)
+		case <-time.After(time.Second):
+			t.Fatal("timed out resolving order")
+		}
+	})
+
+	t.Run("returns unknown order when ctx is done during the grace period", func(t *testing.T) {
+		client, clock := newClient(t, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		done := make(chan *cdcexchange.ResolvedCreateOrderResult)
+		go func() {
+			res, err := client.CreateOrderResolved(ctx, req)
+			assert.NoError(t, err)
+			done <- res
+		}()
+
+		clock.BlockUntil(1)
+		cancel()
+
+		select {
+		case res := <-done:
+			require.NotNil(t, res)
+			assert.Equal(t, cdcexchange.CreateOrderOutcomeUnknown, res.Outcome)
+			assert.Empty(t, res.OrderID)
+			assert.True(t, errors.Is(res.ResolveErr, context.Canceled))
+		case <-time.After(time.Second):
+			t.Fatal("timed out resolving order")
+		}
+	})
+}
+
+func TestClient_CreateOrderResolved_GeneratesClientOID(t *testing.T) {
+	s, requests := resolverServer(t, func(w http.ResponseWriter, r *http.Request) string {
+		return okResponse(`{"order_id":"1"}`)
+	}, "", "", 0)
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	res, err := client.CreateOrderResolved(context.Background(), cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeMarket,
+		Quantity:       decimal.NewFromInt(1),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, cdcexchange.CreateOrderOutcomeCreated, res.Outcome)
+	assert.Len(t, res.ClientOID, 32)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, res.ClientOID, reqs[0].Params["client_oid"])
+}
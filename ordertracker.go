@@ -0,0 +1,87 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// OrderPredicate reports whether an order has reached the state being waited for by WaitForOrder.
+type OrderPredicate func(order Order) bool
+
+// WithOrderPollPolicy will initialise the Client to poll order details with the given policy while waiting for orders
+// (see WaitForOrder). Default: 1s, doubling up to 30s.
+func WithOrderPollPolicy(policy PollPolicy) ClientOption {
+	return func(c *Client) error {
+		policy, err := policy.withDefaults("policy")
+		if err != nil {
+			return err
+		}
+
+		c.orderPollPolicy = policy
+		return nil
+	}
+}
+
+// OrderFilled matches orders which have been fully filled.
+func OrderFilled(order Order) bool {
+	return order.Status == OrderStatusFilled
+}
+
+// OrderCancelled matches orders which have been cancelled.
+func OrderCancelled(order Order) bool {
+	return order.Status == OrderStatusCancelled
+}
+
+// OrderPartiallyFilled matches orders which have been at least partially filled.
+func OrderPartiallyFilled(order Order) bool {
+	return order.CumulativeQuantity.IsPositive()
+}
+
+// WaitForOrder polls the order details with backoff until the order matches predicate or reaches a terminal status
+// (CANCELED, FILLED, REJECTED or EXPIRED).
+//
+// The final order details are returned, along with the trades of the order. If the order reached a terminal status
+// without matching predicate (e.g. it was cancelled while waiting for it to be filled), the details are still returned,
+// so OrderInfo.Status should be checked.
+//
+// predicate can be left nil to wait for a terminal status.
+//
+// Polling continues while the order is not found, or the order details cannot be fetched due to a transient failure
+// (see WithRetry), until ctx is done. Any other error is returned immediately.
+//
+// Method: private/get-order-detail
+func (c *Client) WaitForOrder(ctx context.Context, orderID string, predicate OrderPredicate) (*GetOrderDetailResult, error) {
+	if orderID == "" {
+		return nil, errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
+	}
+
+	policy, err := c.orderPollPolicy.withDefaults("policy")
+	if err != nil {
+		return nil, err
+	}
+
+	var detail *GetOrderDetailResult
+	err = c.poll(ctx, policy, func() (bool, error) {
+		res, err := c.GetOrderDetail(ctx, orderID)
+		switch {
+		case err != nil && retryable(err, true):
+			// transient failures (e.g. rate limits or system errors) are polled through.
+			return false, nil
+		case err != nil:
+			return false, err
+		case res.OrderInfo.OrderID == "":
+			// the order is not found, which it can be for a short time after it is created.
+			return false, nil
+		}
+		detail = res
+
+		return (predicate != nil && predicate(res.OrderInfo)) || res.OrderInfo.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for order: %w", err)
+	}
+
+	return detail, nil
+}
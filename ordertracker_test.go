@@ -0,0 +1,278 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// orderDetailServer serves each order detail result in turn from private/get-order-detail,
+// repeating the last once they are exhausted.
+func orderDetailServer(t *testing.T, results ...string) (*httptest.Server, func() []api.Request) {
+	return apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		assert.Equal(t, cdcexchange.MethodGetOrderDetail, req.Method)
+
+		return okResponse(nthOrLast(results, n))
+	})
+}
+
+func TestWithOrderPollPolicy_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithOrderPollPolicy(cdcexchange.PollPolicy{MinInterval: time.Minute, MaxInterval: time.Second}),
+	)
+	require.Error(t, err)
+
+	assert.Empty(t, client)
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "policy.MaxInterval", Reason: "cannot be less than policy.MinInterval"}, err)
+}
+
+func TestClient_WaitForOrder(t *testing.T) {
+	const orderID = "1"
+	order := func(status cdcexchange.OrderStatus, cumulativeQuantity string, trades ...string) string {
+		tradeList := ""
+		for i, tradeID := range trades {
+			if i > 0 {
+				tradeList += ","
+			}
+			tradeList += fmt.Sprintf(`{"trade_id":"%s","order_id":"%s"}`, tradeID, orderID)
+		}
+		return fmt.Sprintf(`{"trade_list":[%s],"order_info":{"order_id":"%s","status":"%s","cumulative_quantity":"%s"}}`,
+			tradeList, orderID, string(status), cumulativeQuantity)
+	}
+
+	t.Run("returns error when order id is empty", func(t *testing.T) {
+		client, err := cdcexchange.New("api key", "secret key")
+		require.NoError(t, err)
+
+		res, err := client.WaitForOrder(context.Background(), "", nil)
+		require.Error(t, err)
+
+		assert.Nil(t, res)
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}, err)
+	})
+
+	t.Run("polls with backoff until the predicate matches", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Now())
+		s, requests := orderDetailServer(t,
+			order(cdcexchange.OrderStatusActive, "0"),
+			order(cdcexchange.OrderStatusActive, "0"),
+			order(cdcexchange.OrderStatusActive, "0.5", "a"),
+			order(cdcexchange.OrderStatusFilled, "1", "a", "b"),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithOrderPollPolicy(cdcexchange.PollPolicy{MinInterval: time.Second, MaxInterval: 2 * time.Second}),
+		)
+		require.NoError(t, err)
+
+		type result struct {
+			res *cdcexchange.GetOrderDetailResult
+			err error
+		}
+		done := make(chan result)
+		go func() {
+			res, err := client.WaitForOrder(context.Background(), orderID, cdcexchange.OrderFilled)
+			done <- result{res: res, err: err}
+		}()
+
+		for _, interval := range []time.Duration{time.Second, 2 * time.Second, 2 * time.Second} {
+			clock.BlockUntil(1)
+			clock.Advance(interval)
+		}
+
+		select {
+		case r := <-done:
+			require.NoError(t, r.err)
+			assert.Equal(t, cdcexchange.OrderStatusFilled, r.res.OrderInfo.Status)
+			require.Len(t, r.res.TradeList, 2)
+			assert.Equal(t, "b", r.res.TradeList[1].TradeID)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for order")
+		}
+
+		reqs := requests()
+		require.Len(t, reqs, 4)
+		assert.Equal(t, orderID, reqs[0].Params["order_id"])
+	})
+
+	t.Run("returns once the order is partially filled", func(t *testing.T) {
+		s, requests := orderDetailServer(t,
+			order(cdcexchange.OrderStatusActive, "0.5", "a"),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		res, err := client.WaitForOrder(context.Background(), orderID, cdcexchange.OrderPartiallyFilled)
+		require.NoError(t, err)
+
+		assert.Equal(t, cdcexchange.OrderStatusActive, res.OrderInfo.Status)
+		assert.Len(t, requests(), 1)
+	})
+
+	t.Run("returns the order once it reaches a terminal status without matching the predicate", func(t *testing.T) {
+		s, requests := orderDetailServer(t,
+			order(cdcexchange.OrderStatusCancelled, "0"),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		res, err := client.WaitForOrder(context.Background(), orderID, cdcexchange.OrderFilled)
+		require.NoError(t, err)
+
+		assert.Equal(t, cdcexchange.OrderStatusCancelled, res.OrderInfo.Status)
+		assert.Len(t, requests(), 1)
+	})
+
+	t.Run("keeps polling while the order is not found", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Now())
+		s, requests := orderDetailServer(t,
+			`{"trade_list":[],"order_info":{}}`,
+			order(cdcexchange.OrderStatusFilled, "1"),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			res, err := client.WaitForOrder(context.Background(), orderID, nil)
+			if err == nil {
+				assert.Equal(t, cdcexchange.OrderStatusFilled, res.OrderInfo.Status)
+			}
+			done <- err
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for order")
+		}
+		assert.Len(t, requests(), 2)
+	})
+
+	t.Run("keeps polling through retryable errors", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Now())
+		responses := []struct {
+			statusCode int
+			body       string
+		}{
+			{http.StatusInternalServerError, `{"id":0,"method":"","code":10001}`},
+			{http.StatusTooManyRequests, `{"id":0,"method":"","code":10006}`},
+			{http.StatusOK, okResponse(order(cdcexchange.OrderStatusFilled, "1"))},
+		}
+		s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+			w.WriteHeader(responses[n].statusCode)
+			return responses[n].body
+		})
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithOrderPollPolicy(cdcexchange.PollPolicy{MinInterval: time.Second, MaxInterval: time.Second}),
+		)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			res, err := client.WaitForOrder(context.Background(), orderID, nil)
+			if err == nil {
+				assert.Equal(t, cdcexchange.OrderStatusFilled, res.OrderInfo.Status)
+			}
+			done <- err
+		}()
+
+		for i := 0; i < 2; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+		}
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for order")
+		}
+		assert.Len(t, requests(), 3)
+	})
+
+	t.Run("returns error from request", func(t *testing.T) {
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithHTTPClient(&http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		res, err := client.WaitForOrder(context.Background(), orderID, nil)
+		require.Error(t, err)
+
+		assert.Nil(t, res)
+		assert.True(t, errors.Is(err, cdcerrors.ErrIllegalIP))
+	})
+
+	t.Run("returns error once ctx is done", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Now())
+		s, _ := orderDetailServer(t, order(cdcexchange.OrderStatusActive, "0"))
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := client.WaitForOrder(ctx, orderID, nil)
+			done <- err
+		}()
+
+		clock.BlockUntil(1)
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.True(t, errors.Is(err, context.Canceled))
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for order")
+		}
+	})
+}
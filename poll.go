@@ -0,0 +1,67 @@
+package cdcexchange
+
+import (
+	"context"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	defaultPollMinInterval = time.Second
+	defaultPollMaxInterval = 30 * time.Second
+)
+
+// PollPolicy configures how often a REST endpoint is polled while waiting for an asynchronous outcome.
+type PollPolicy struct {
+	// MinInterval is the delay before the second poll, doubling after each subsequent poll.
+	// (Default: 1s)
+	MinInterval time.Duration
+	// MaxInterval is the maximum delay between polls.
+	// (Default: 30s)
+	MaxInterval time.Duration
+}
+
+// withDefaults validates the policy and fills in any unset intervals.
+func (p PollPolicy) withDefaults(parameter string) (PollPolicy, error) {
+	switch {
+	case p.MinInterval < 0:
+		return PollPolicy{}, errors.InvalidParameterError{Parameter: parameter + ".MinInterval", Reason: "cannot be less than 0"}
+	case p.MaxInterval < 0:
+		return PollPolicy{}, errors.InvalidParameterError{Parameter: parameter + ".MaxInterval", Reason: "cannot be less than 0"}
+	}
+
+	if p.MinInterval == 0 {
+		p.MinInterval = defaultPollMinInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = defaultPollMaxInterval
+	}
+	if p.MaxInterval < p.MinInterval {
+		return PollPolicy{}, errors.InvalidParameterError{Parameter: parameter + ".MaxInterval", Reason: "cannot be less than " + parameter + ".MinInterval"}
+	}
+
+	return p, nil
+}
+
+// poll calls attempt immediately and then with exponential backoff on the Client's clock,
+// until it returns done, returns an error or ctx is done.
+func (c *Client) poll(ctx context.Context, policy PollPolicy, attempt func() (done bool, err error)) error {
+	interval := policy.MinInterval
+	for {
+		done, err := attempt()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(interval):
+		}
+
+		if interval *= 2; interval > policy.MaxInterval {
+			interval = policy.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cdcexchange
+
+import (
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// RateLimit allows a burst of Requests per method, refilled evenly over Interval.
+//
+// The Exchange's published limits are applied by default, e.g. private/create-order is limited to
+// 15 requests per 100ms, while other private methods are limited to 3 requests per 100ms.
+type RateLimit struct {
+	// Requests is the number of requests allowed per Interval, 0 disables rate limiting for the method.
+	Requests int
+	// Interval is the duration over which Requests are allowed.
+	Interval time.Duration
+}
+
+// WithRateLimit will initialise the Client to limit REST requests for method (e.g. private/create-order)
+// to limit, instead of the Exchange's published limit.
+//
+// Requests which would exceed the limit block until they can be made, or their ctx is done.
+func WithRateLimit(method string, limit RateLimit) ClientOption {
+	return func(c *Client) error {
+		switch {
+		case method == "":
+			return errors.InvalidParameterError{Parameter: "method", Reason: "cannot be empty"}
+		case limit.Requests < 0:
+			return errors.InvalidParameterError{Parameter: "limit.Requests", Reason: "cannot be less than 0"}
+		case limit.Requests > 0 && limit.Interval <= 0:
+			return errors.InvalidParameterError{Parameter: "limit.Interval", Reason: "must be greater than 0"}
+		}
+
+		c.requester.RateLimiter.SetLimit(method, api.RateLimit{
+			Requests: limit.Requests,
+			Interval: limit.Interval,
+		})
+		return nil
+	}
+}
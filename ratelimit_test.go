@@ -0,0 +1,108 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+)
+
+func TestWithRateLimit_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		limit       cdcexchange.RateLimit
+		expectedErr error
+	}{
+		{
+			name:        "returns error when method is empty",
+			limit:       cdcexchange.RateLimit{Requests: 1, Interval: time.Second},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "method", Reason: "cannot be empty"},
+		},
+		{
+			name:        "returns error when requests is less than 0",
+			method:      cdcexchange.MethodCreateOrder,
+			limit:       cdcexchange.RateLimit{Requests: -1, Interval: time.Second},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "limit.Requests", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when interval is not set",
+			method:      cdcexchange.MethodCreateOrder,
+			limit:       cdcexchange.RateLimit{Requests: 1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "limit.Interval", Reason: "must be greater than 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithRateLimit(tt.method, tt.limit))
+			require.Error(t, err)
+
+			assert.Empty(t, client)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetInstruments)
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.InstrumentsResponse{}))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRateLimit(cdcexchange.MethodGetInstruments, cdcexchange.RateLimit{Requests: 1, Interval: time.Second}),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).Times(2)
+
+	_, err = client.GetInstruments(ctx)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := client.GetInstruments(ctx)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for rate limited request")
+	}
+}
@@ -0,0 +1,129 @@
+package cdcexchange
+
+import (
+	"context"
+	goerrors "errors"
+	"math/rand"
+	"net/url"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy configures how failed REST requests are retried.
+//
+// Requests are retried after transport failures, 5xx responses and the following response errors:
+//   - errors.ErrSystemError
+//   - errors.ErrTooManyRequests
+//   - errors.ErrInvalidNonce
+//
+// Every other response error (e.g. errors.ErrNegativeBalance or errors.ErrUnauthorized) is returned immediately.
+//
+// Private requests are re-signed with a new ID & nonce on each attempt.
+//
+// Requests which are not idempotent (private/create-order without a ClientOID, private/create-withdrawal without a ClientWid)
+// could be duplicated if they are retried after an ambiguous failure, so they are only retried after
+// errors.ErrTooManyRequests or errors.ErrInvalidNonce, which are returned before the request is processed.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts made for each request (including the first attempt).
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, doubling on each subsequent retry.
+	// (Default: 100ms)
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries.
+	// (Default: 5s)
+	MaxBackoff time.Duration
+}
+
+// WithRetry will enable failed REST requests to be retried with exponential backoff.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		switch {
+		case policy.MaxAttempts < 1:
+			return errors.InvalidParameterError{Parameter: "policy.MaxAttempts", Reason: "cannot be less than 1"}
+		case policy.MinBackoff < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "cannot be less than 0"}
+		case policy.MaxBackoff < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than 0"}
+		}
+
+		if policy.MinBackoff == 0 {
+			policy.MinBackoff = defaultRetryMinBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			return errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"}
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// retry calls attempt until it succeeds, returns an error which is not retryable or the RetryPolicy is exhausted.
+//
+// idempotent must be false if repeating the request after an ambiguous failure could duplicate its effect.
+// attempt must reset anything it decodes into (e.g. the response), so that no state is kept from a failed attempt.
+func (c *Client) retry(ctx context.Context, idempotent bool, attempt func() error) error {
+	for i := 1; ; i++ {
+		err := attempt()
+		if goerrors.Is(err, errors.ErrInvalidNonce) {
+			c.resyncServerTime(ctx)
+		}
+		if err == nil || c.retryPolicy == nil || i >= c.retryPolicy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		if !retryable(err, idempotent) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-c.clock.After(c.retryPolicy.backoff(i - 1)):
+		}
+	}
+}
+
+// retryable returns whether a request which failed with err can be retried.
+func retryable(err error, idempotent bool) bool {
+	if goerrors.Is(err, errors.ErrTooManyRequests) || goerrors.Is(err, errors.ErrInvalidNonce) {
+		// the request was rejected before being processed.
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+
+	var responseErr errors.ResponseError
+	if goerrors.As(err, &responseErr) {
+		if goerrors.Is(responseErr.Err, errors.ErrSystemError) {
+			return true
+		}
+		// a 5xx without a known response code (e.g. from a gateway) is treated as a transport failure.
+		return responseErr.HTTPStatusCode >= 500 && (responseErr.Code == 0 || goerrors.Is(responseErr.Err, errors.ErrUnexpectedError))
+	}
+
+	var urlErr *url.Error
+	return goerrors.As(err, &urlErr)
+}
+
+// backoff returns the jittered delay before the given (0-based) retry.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MaxBackoff
+	if retry < 32 {
+		if exp := p.MinBackoff << uint(retry); exp > 0 && exp < p.MaxBackoff {
+			d = exp
+		}
+	}
+
+	// jitter over the upper half of the delay so that concurrent requests do not retry in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
@@ -0,0 +1,304 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestWithRetry_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      cdcexchange.RetryPolicy
+		expectedErr error
+	}{
+		{
+			name:        "returns error when max attempts is less than 1",
+			policy:      cdcexchange.RetryPolicy{},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxAttempts", Reason: "cannot be less than 1"},
+		},
+		{
+			name:        "returns error when min backoff is less than 0",
+			policy:      cdcexchange.RetryPolicy{MaxAttempts: 1, MinBackoff: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when max backoff is less than 0",
+			policy:      cdcexchange.RetryPolicy{MaxAttempts: 1, MaxBackoff: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when max backoff is less than min backoff",
+			policy:      cdcexchange.RetryPolicy{MaxAttempts: 1, MinBackoff: time.Minute, MaxBackoff: time.Second},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithRetry(tt.policy))
+			require.Error(t, err)
+
+			assert.Empty(t, client)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	order := cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeMarket,
+		Notional:       decimal.RequireFromString("10"),
+	}
+
+	// responses are the status code & response code returned for each attempt.
+	type response struct {
+		statusCode int
+		code       string
+	}
+	tests := []struct {
+		name             string
+		clientOID        string
+		responses        []response
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			name:             "retries system error",
+			clientOID:        "some client oid",
+			responses:        []response{{http.StatusInternalServerError, "10001"}, {http.StatusOK, "0"}},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries gateway error",
+			clientOID:        "some client oid",
+			responses:        []response{{http.StatusBadGateway, ""}, {http.StatusOK, "0"}},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries too many requests without client oid",
+			responses:        []response{{http.StatusTooManyRequests, "10006"}, {http.StatusOK, "0"}},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "does not retry system error without client oid",
+			responses:        []response{{http.StatusInternalServerError, "10001"}},
+			expectedAttempts: 1,
+			expectedErr:      cdcerrors.ErrSystemError,
+		},
+		{
+			name:             "does not retry insufficient balance",
+			clientOID:        "some client oid",
+			responses:        []response{{http.StatusBadRequest, "20002"}},
+			expectedAttempts: 1,
+			expectedErr:      cdcerrors.ErrNegativeBalance,
+		},
+		{
+			name:             "returns last error once attempts are exhausted",
+			clientOID:        "some client oid",
+			responses:        []response{{http.StatusInternalServerError, "10001"}, {http.StatusInternalServerError, "10001"}, {http.StatusInternalServerError, "10001"}},
+			expectedAttempts: 3,
+			expectedErr:      cdcerrors.ErrSystemError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+				attempts           int32
+			)
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				require.LessOrEqual(t, int(attempt), len(tt.responses))
+
+				var body api.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				// each attempt is re-signed with a new id & nonce.
+				assert.Equal(t, int64(attempt), body.ID)
+				assert.Equal(t, now.Add(time.Duration(attempt-1)*2*time.Second).UnixMilli(), body.Nonce)
+
+				res := tt.responses[attempt-1]
+				w.WriteHeader(res.statusCode)
+				if res.code == "" {
+					_, err := w.Write([]byte("<html>bad gateway</html>"))
+					require.NoError(t, err)
+					return
+				}
+
+				require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.CreateOrderResponse{
+					BaseResponse: api.BaseResponse{Code: json.Number(res.code)},
+					Result:       cdcexchange.CreateOrderResult{OrderID: "1"},
+				}))
+			}))
+			t.Cleanup(s.Close)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(s.Client()),
+				cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+				cdcexchange.WithRetry(cdcexchange.RetryPolicy{
+					MaxAttempts: 3,
+					MinBackoff:  2 * time.Second,
+					MaxBackoff:  2 * time.Second,
+				}),
+			)
+			require.NoError(t, err)
+
+			req := order
+			req.ClientOID = tt.clientOID
+
+			params := map[string]interface{}{
+				"instrument_name": req.InstrumentName,
+				"side":            req.Side,
+				"type":            req.Type,
+				"notional":        "10",
+			}
+			if tt.clientOID != "" {
+				params["client_oid"] = tt.clientOID
+			}
+
+			for i := 0; i < tt.expectedAttempts; i++ {
+				idGenerator.EXPECT().Generate().Return(int64(i + 1))
+				signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+					APIKey:    apiKey,
+					SecretKey: secretKey,
+					ID:        int64(i + 1),
+					Method:    cdcexchange.MethodCreateOrder,
+					Timestamp: now.Add(time.Duration(i) * 2 * time.Second).UnixMilli(),
+					Params:    params,
+				}).Return(signature, nil)
+			}
+
+			type result struct {
+				res *cdcexchange.CreateOrderResult
+				err error
+			}
+			done := make(chan result)
+			go func() {
+				res, err := client.CreateOrder(ctx, req)
+				done <- result{res: res, err: err}
+			}()
+
+			for i := 1; i < tt.expectedAttempts; i++ {
+				// the jittered backoff is between 1s & 2s.
+				clock.BlockUntil(1)
+				clock.Advance(2 * time.Second)
+			}
+
+			select {
+			case r := <-done:
+				assert.Equal(t, int32(tt.expectedAttempts), atomic.LoadInt32(&attempts))
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(r.err, tt.expectedErr))
+					assert.Nil(t, r.res)
+					return
+				}
+
+				require.NoError(t, r.err)
+				assert.Equal(t, "1", r.res.OrderID)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for order")
+			}
+		})
+	}
+}
+
+func TestClient_Retry_DiscardsFailedAttemptResponse(t *testing.T) {
+	const id = int64(1234)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+		attempts    int32
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// the failed attempt includes a trade list, which the successful attempt does not.
+			w.WriteHeader(http.StatusInternalServerError)
+			_, err := w.Write([]byte(`{"id":1234,"code":10001,"result":{"trade_list":[{"trade_id":"1"}]}}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"id":1234,"code":0,"result":{"order_info":{"order_id":"1"}}}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithRetry(cdcexchange.RetryPolicy{
+			MaxAttempts: 2,
+			MinBackoff:  time.Second,
+			MaxBackoff:  time.Second,
+		}),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).Times(2)
+
+	type result struct {
+		res *cdcexchange.GetOrderDetailResult
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		res, err := client.GetOrderDetail(ctx, "1")
+		done <- result{res: res, err: err}
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	select {
+	case r := <-done:
+		require.NoError(t, r.err)
+		assert.Equal(t, "1", r.res.OrderInfo.OrderID)
+		assert.Empty(t, r.res.TradeList)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order detail")
+	}
+}
@@ -0,0 +1,158 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	// maxServerTimeSamples is the number of recent offsets the estimate is taken from.
+	maxServerTimeSamples = 8
+	// serverTimeSyncDepth is the depth of the book fetched to sync the server time.
+	serverTimeSyncDepth = 10
+)
+
+// serverTime estimates the offset of the Exchange's clock from the Client's clock.
+//
+// Offsets are sampled from the timestamps of market data, which can be older than the time the response was sent,
+// so each sample underestimates the offset and the largest recent sample is used as the estimate.
+type serverTime struct {
+	mu         sync.Mutex
+	enabled    bool
+	instrument string
+	samples    []time.Duration
+	offset     time.Duration
+}
+
+// WithServerTimeSync will initialise the Client to estimate the offset of the Exchange's clock from its own
+// and apply it to request nonces, so that requests are not rejected with errors.ErrInvalidNonce
+// (nonce differs by more than 30 seconds from server) when the local clock drifts.
+//
+// The offset is estimated from the timestamps of public/get-book & public/get-ticker responses.
+// After errors.ErrInvalidNonce is returned, it is re-estimated from a public/get-book snapshot of instrument (e.g. BTC_USDT).
+func WithServerTimeSync(instrument string) ClientOption {
+	return func(c *Client) error {
+		if instrument == "" {
+			return errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+		}
+
+		c.serverTime.mu.Lock()
+		defer c.serverTime.mu.Unlock()
+
+		c.serverTime.enabled = true
+		c.serverTime.instrument = instrument
+		return nil
+	}
+}
+
+// ServerTimeOffset returns the estimated offset of the Exchange's clock from the Client's clock
+// (positive if the Exchange's clock is ahead), which is added to request nonces.
+//
+// 0 is returned if WithServerTimeSync is not set or no responses have been sampled yet.
+func (c *Client) ServerTimeOffset() time.Duration {
+	c.serverTime.mu.Lock()
+	defer c.serverTime.mu.Unlock()
+
+	return c.serverTime.offset
+}
+
+// SyncServerTime re-estimates the offset of the Exchange's clock, discarding previous samples.
+//
+// It is called automatically after errors.ErrInvalidNonce is returned if WithServerTimeSync is set.
+func (c *Client) SyncServerTime(ctx context.Context) error {
+	c.serverTime.mu.Lock()
+	instrument := c.serverTime.instrument
+	c.serverTime.mu.Unlock()
+
+	if instrument == "" {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "must be set with WithServerTimeSync"}
+	}
+
+	sent := c.clock.Now()
+	res, err := c.GetBook(ctx, instrument, serverTimeSyncDepth)
+	if err != nil {
+		return fmt.Errorf("failed to get server time: %w", err)
+	}
+	if len(res.Data) == 0 {
+		return fmt.Errorf("failed to get server time: no data returned for %s", instrument)
+	}
+
+	offset := sampleServerTime(sent, c.clock.Now(), res.Data[0].Timestamp.Time())
+
+	c.serverTime.mu.Lock()
+	defer c.serverTime.mu.Unlock()
+
+	c.serverTime.samples = []time.Duration{offset}
+	c.serverTime.offset = offset
+
+	return nil
+}
+
+// nonce returns the current time in milliseconds, adjusted to the Exchange's clock.
+func (c *Client) nonce() int64 {
+	c.serverTime.mu.Lock()
+	offset := c.serverTime.offset
+	c.serverTime.mu.Unlock()
+
+	return c.clock.Now().Add(offset).UnixMilli()
+}
+
+// observeServerTime samples the offset of the Exchange's clock from a response which was sent & received at
+// the given local times and contains data timestamped at server.
+func (c *Client) observeServerTime(sent time.Time, received time.Time, server time.Time) {
+	if server.IsZero() {
+		return
+	}
+
+	c.serverTime.mu.Lock()
+	defer c.serverTime.mu.Unlock()
+
+	if !c.serverTime.enabled {
+		return
+	}
+
+	c.serverTime.samples = append(c.serverTime.samples, sampleServerTime(sent, received, server))
+	if len(c.serverTime.samples) > maxServerTimeSamples {
+		c.serverTime.samples = c.serverTime.samples[1:]
+	}
+
+	c.serverTime.offset = c.serverTime.samples[0]
+	for _, sample := range c.serverTime.samples[1:] {
+		if sample > c.serverTime.offset {
+			c.serverTime.offset = sample
+		}
+	}
+}
+
+// resyncServerTime re-estimates the offset after a request is rejected for its nonce.
+func (c *Client) resyncServerTime(ctx context.Context) {
+	c.serverTime.mu.Lock()
+	enabled := c.serverTime.enabled
+	c.serverTime.mu.Unlock()
+
+	if enabled {
+		// if the resync fails, the previous estimate is kept.
+		_ = c.SyncServerTime(ctx)
+	}
+}
+
+// latestTickerTime returns the timestamp of the most recently updated ticker, which is the closest to the server time.
+func latestTickerTime(tickers []Ticker) time.Time {
+	var latest time.Time
+	for _, ticker := range tickers {
+		if t := ticker.Timestamp.Time(); t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// sampleServerTime returns the offset of server from the local time halfway between sent & received.
+func sampleServerTime(sent time.Time, received time.Time, server time.Time) time.Duration {
+	return server.Sub(sent.Add(received.Sub(sent) / 2))
+}
@@ -0,0 +1,154 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestWithServerTimeSync_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithServerTimeSync(""))
+	require.Error(t, err)
+
+	assert.Empty(t, client)
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}, err)
+}
+
+func TestClient_SyncServerTime_Error(t *testing.T) {
+	client, err := cdcexchange.New("api key", "secret key")
+	require.NoError(t, err)
+
+	err = client.SyncServerTime(context.Background())
+	assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "must be set with WithServerTimeSync"}, err)
+}
+
+func TestClient_ServerTimeSync(t *testing.T) {
+	const (
+		apiKey     = "some api key"
+		secretKey  = "some secret key"
+		id         = int64(1234)
+		signature  = "some signature"
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+		// serverOffset is how far the server's clock is ahead of the local clock.
+		serverOffset = int64(45 * time.Second)
+		rejectNonce  int32
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, cdcexchange.MethodGetBook) {
+			assert.Equal(t, instrument, r.URL.Query().Get("instrument_name"))
+
+			serverTime := now.Add(time.Duration(atomic.LoadInt64(&serverOffset)))
+			_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":10,"data":[{"bids":[],"asks":[],"t":%d}]}}`, instrument, serverTime.UnixMilli())))
+			require.NoError(t, err)
+			return
+		}
+
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetAccountSummary)
+
+		res := cdcexchange.AccountSummaryResponse{}
+		if atomic.CompareAndSwapInt32(&rejectNonce, 1, 0) {
+			w.WriteHeader(http.StatusBadRequest)
+			res.Code = "10007"
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithServerTimeSync(instrument),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+	expectSignature := func(offset time.Duration) {
+		signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+			APIKey:    apiKey,
+			SecretKey: secretKey,
+			ID:        id,
+			Method:    cdcexchange.MethodGetAccountSummary,
+			Timestamp: now.Add(offset).UnixMilli(),
+			Params:    map[string]interface{}{},
+		}).Return(signature, nil)
+	}
+
+	assert.Equal(t, time.Duration(0), client.ServerTimeOffset())
+
+	// the offset is sampled from market data.
+	_, err = client.GetBook(ctx, instrument, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, client.ServerTimeOffset())
+
+	expectSignature(45 * time.Second)
+	_, err = client.GetAccountSummary(ctx, "")
+	require.NoError(t, err)
+
+	// the server's clock drifts, so the next nonce is rejected and the offset is resynced.
+	atomic.StoreInt64(&serverOffset, int64(-time.Minute))
+	atomic.StoreInt32(&rejectNonce, 1)
+
+	expectSignature(45 * time.Second)
+	_, err = client.GetAccountSummary(ctx, "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cdcerrors.ErrInvalidNonce))
+	assert.Equal(t, -time.Minute, client.ServerTimeOffset())
+
+	expectSignature(-time.Minute)
+	_, err = client.GetAccountSummary(ctx, "")
+	require.NoError(t, err)
+}
+
+func TestClient_ServerTimeSync_Disabled(t *testing.T) {
+	const instrument = "BTC_USDT"
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverTime := time.Now().Add(time.Hour)
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":0,"method":"","code":0,"result":{"instrument_name":"%s","depth":10,"data":[{"bids":[],"asks":[],"t":%d}]}}`, instrument, serverTime.UnixMilli())))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetBook(context.Background(), instrument, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Duration(0), client.ServerTimeOffset())
+}
@@ -0,0 +1,142 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// SubAccountClient is a Client scoped to act on behalf of a specific sub-account of the master account.
+//
+// Requests are signed with the Client's (master) API key, so the Client's configuration (e.g. retries & rate limits)
+// also applies to the SubAccountClient.
+//
+// All methods are safe for concurrent use.
+type SubAccountClient struct {
+	client *Client
+	uuid   string
+
+	mu         sync.Mutex
+	masterUUID string
+}
+
+// SubAccount returns a SubAccountClient which acts on behalf of the sub-account with the given UUID.
+func (c *Client) SubAccount(uuid string) *SubAccountClient {
+	return &SubAccountClient{
+		client: c,
+		uuid:   uuid,
+	}
+}
+
+// UUID returns the UUID of the sub-account.
+func (s *SubAccountClient) UUID() string {
+	return s.uuid
+}
+
+// GetBalance gets the balance of the sub-account.
+//
+// errors.ErrSubAccountNotFound is returned if the sub-account is not a sub-account of the master account.
+//
+// Method: private/get-subaccount-balances
+func (s *SubAccountClient) GetBalance(ctx context.Context) (*SubAccountBalance, error) {
+	if s.uuid == "" {
+		return nil, errors.InvalidParameterError{Parameter: "uuid", Reason: "cannot be empty"}
+	}
+
+	balances, err := s.client.GetSubAccountBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sub-account balances: %w", err)
+	}
+
+	for i := range balances {
+		if balances[i].Account == s.uuid {
+			return &balances[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("sub-account %s: %w", s.uuid, errors.ErrSubAccountNotFound)
+}
+
+// GetTransferHistory gets the history of transfers between the master account & the sub-account.
+//
+// req.SubAccountUUID is set to the UUID of the sub-account.
+//
+// Method: private/subaccount/get-transfer-history
+func (s *SubAccountClient) GetTransferHistory(ctx context.Context, req GetSubAccountTransferHistoryRequest) ([]SubAccountTransferRecord, error) {
+	if s.uuid == "" {
+		return nil, errors.InvalidParameterError{Parameter: "uuid", Reason: "cannot be empty"}
+	}
+
+	req.SubAccountUUID = s.uuid
+	return s.client.GetSubAccountTransferHistory(ctx, req)
+}
+
+// TransferIn transfers funds from the master account to the sub-account.
+//
+// The UUID of the master account is looked up with GetSubAccounts on first use.
+//
+// Method: private/subaccount/transfer
+func (s *SubAccountClient) TransferIn(ctx context.Context, currency string, amount decimal.Decimal) error {
+	masterUUID, err := s.masterAccountUUID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.client.SubAccountTransfer(ctx, SubAccountTransferRequest{
+		From:     masterUUID,
+		To:       s.uuid,
+		Currency: currency,
+		Amount:   amount,
+	})
+}
+
+// TransferOut transfers funds from the sub-account to the master account.
+//
+// The UUID of the master account is looked up with GetSubAccounts on first use.
+//
+// Method: private/subaccount/transfer
+func (s *SubAccountClient) TransferOut(ctx context.Context, currency string, amount decimal.Decimal) error {
+	masterUUID, err := s.masterAccountUUID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.client.SubAccountTransfer(ctx, SubAccountTransferRequest{
+		From:     s.uuid,
+		To:       masterUUID,
+		Currency: currency,
+		Amount:   amount,
+	})
+}
+
+// masterAccountUUID returns the UUID of the sub-account's master account, caching it once found.
+func (s *SubAccountClient) masterAccountUUID(ctx context.Context) (string, error) {
+	if s.uuid == "" {
+		return "", errors.InvalidParameterError{Parameter: "uuid", Reason: "cannot be empty"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.masterUUID != "" {
+		return s.masterUUID, nil
+	}
+
+	subAccounts, err := s.client.GetSubAccounts(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sub-accounts: %w", err)
+	}
+
+	for _, subAccount := range subAccounts {
+		if subAccount.UUID == s.uuid {
+			s.masterUUID = subAccount.MasterAccountUUID
+			return s.masterUUID, nil
+		}
+	}
+
+	return "", fmt.Errorf("sub-account %s: %w", s.uuid, errors.ErrSubAccountNotFound)
+}
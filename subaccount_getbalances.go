@@ -0,0 +1,139 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodGetSubAccountBalances = "private/get-subaccount-balances"
+)
+
+type (
+	// GetSubAccountBalancesResponse is the base response returned from the private/get-subaccount-balances API.
+	GetSubAccountBalancesResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetSubAccountBalancesResult `json:"result"`
+	}
+
+	// GetSubAccountBalancesResult is the result returned from the private/get-subaccount-balances API.
+	GetSubAccountBalancesResult struct {
+		// Data is the array of balances, one per sub-account.
+		Data []SubAccountBalance `json:"data"`
+	}
+
+	// SubAccountBalance represents the balance of a sub-account.
+	SubAccountBalance struct {
+		// Account is the UUID of the sub-account.
+		Account string `json:"account"`
+		// InstrumentName is the currency the totals are valued in (e.g. USD).
+		InstrumentName string `json:"instrument_name"`
+		// TotalAvailableBalance is the balance available to open new positions.
+		TotalAvailableBalance decimal.Decimal `json:"total_available_balance"`
+		// TotalMarginBalance is the total balance including unrealized PnL.
+		TotalMarginBalance decimal.Decimal `json:"total_margin_balance"`
+		// TotalInitialMargin is the total initial margin requirement of all positions & open orders.
+		TotalInitialMargin decimal.Decimal `json:"total_initial_margin"`
+		// TotalMaintenanceMargin is the total maintenance margin requirement of all positions & open orders.
+		TotalMaintenanceMargin decimal.Decimal `json:"total_maintenance_margin"`
+		// TotalPositionCost is the total cost of all open positions.
+		TotalPositionCost decimal.Decimal `json:"total_position_cost"`
+		// TotalCashBalance is the total cash balance.
+		TotalCashBalance decimal.Decimal `json:"total_cash_balance"`
+		// TotalCollateralValue is the total collateral value.
+		TotalCollateralValue decimal.Decimal `json:"total_collateral_value"`
+		// TotalSessionUnrealizedPnl is the unrealized PnL of the current session.
+		TotalSessionUnrealizedPnl decimal.Decimal `json:"total_session_unrealized_pnl"`
+		// TotalSessionRealizedPnl is the realized PnL of the current session.
+		TotalSessionRealizedPnl decimal.Decimal `json:"total_session_realized_pnl"`
+		// TotalEffectiveLeverage is the effective leverage of all positions.
+		TotalEffectiveLeverage decimal.Decimal `json:"total_effective_leverage"`
+		// PositionLimit is the maximum position size allowed.
+		PositionLimit decimal.Decimal `json:"position_limit"`
+		// UsedPositionLimit is the position size currently used.
+		UsedPositionLimit decimal.Decimal `json:"used_position_limit"`
+		// IsLiquidating is whether the sub-account is being liquidated.
+		IsLiquidating bool `json:"is_liquidating"`
+		// PositionBalances is the balance of each currency held by the sub-account.
+		PositionBalances []PositionBalance `json:"position_balances"`
+	}
+
+	// PositionBalance represents the balance of a specific currency held by a sub-account.
+	PositionBalance struct {
+		// InstrumentName is the symbol of the currency (e.g. BTC).
+		InstrumentName string `json:"instrument_name"`
+		// Quantity is the quantity held.
+		Quantity decimal.Decimal `json:"quantity"`
+		// MarketValue is the market value of the quantity held.
+		MarketValue decimal.Decimal `json:"market_value"`
+		// CollateralAmount is the collateral value of the quantity held, after the haircut.
+		CollateralAmount decimal.Decimal `json:"collateral_amount"`
+		// Haircut is the haircut applied to the collateral value.
+		Haircut decimal.Decimal `json:"haircut"`
+		// MaxWithdrawalBalance is the maximum quantity which can be withdrawn.
+		MaxWithdrawalBalance decimal.Decimal `json:"max_withdrawal_balance"`
+		// ReservedQty is the quantity reserved for open orders.
+		ReservedQty decimal.Decimal `json:"reserved_qty"`
+	}
+)
+
+// GetSubAccountBalances gets the balances of all sub-accounts of the master account.
+//
+// Method: private/get-subaccount-balances
+func (c *Client) GetSubAccountBalances(ctx context.Context) ([]SubAccountBalance, error) {
+	params := make(map[string]interface{})
+
+	var getSubAccountBalancesResponse GetSubAccountBalancesResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetSubAccountBalances,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetSubAccountBalances,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+			Version:   api.V1,
+		}
+
+		getSubAccountBalancesResponse = GetSubAccountBalancesResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetSubAccountBalances, &getSubAccountBalancesResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getSubAccountBalancesResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getSubAccountBalancesResponse.Result.Data, nil
+}
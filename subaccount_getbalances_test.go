@@ -0,0 +1,252 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+func TestClient_GetSubAccountBalances_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		client       http.Client
+		signatureErr error
+		expectedErr  error
+	}{
+		{
+			name:         "returns error given error generating signature",
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error making request",
+			client: http.Client{
+				Transport: roundTripper{
+					err: testErr,
+				},
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "returns error given error response",
+			client: http.Client{
+				Transport: roundTripper{
+					statusCode: http.StatusTeapot,
+					response: api.BaseResponse{
+						Code: "10003",
+					},
+				},
+			},
+			expectedErr: cdcerrors.ResponseError{
+				Code:           10003,
+				HTTPStatusCode: http.StatusTeapot,
+				Err:            cdcerrors.ErrIllegalIP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithHTTPClient(&tt.client),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+			)
+			require.NoError(t, err)
+
+			idGenerator.EXPECT().Generate().Return(id)
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodGetSubAccountBalances,
+				Timestamp: now.UnixMilli(),
+				Params:    map[string]interface{}{},
+			}).Return("signature", tt.signatureErr)
+
+			res, err := client.GetSubAccountBalances(ctx)
+			require.Error(t, err)
+
+			assert.Empty(t, res)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.HTTPStatusCode, responseError.HTTPStatusCode)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_GetSubAccountBalances_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodGetSubAccountBalances)
+		// the endpoint is only available on the v1 API.
+		assert.Contains(t, r.URL.Path, api.V1)
+		t.Cleanup(func() { require.NoError(t, r.Body.Close()) })
+
+		var body api.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		assert.Equal(t, cdcexchange.MethodGetSubAccountBalances, body.Method)
+		assert.Equal(t, id, body.ID)
+		assert.Equal(t, apiKey, body.APIKey)
+		assert.Equal(t, now.UnixMilli(), body.Nonce)
+		assert.Equal(t, signature, body.Signature)
+		assert.Empty(t, body.Params)
+
+		res := `{
+			"id": 0,
+			"method":"",
+			"code":0,
+			"result":{
+				"data":[
+					{
+						"account":"sub uuid",
+						"instrument_name":"USD",
+						"total_available_balance":"4721.05898582",
+						"total_margin_balance":"7595.42571782",
+						"total_initial_margin":"2874.366732",
+						"total_maintenance_margin":"1437.183366",
+						"total_position_cost":"14517.54641301",
+						"total_cash_balance":"7890.00320721",
+						"total_collateral_value":"7651.18811483",
+						"total_session_unrealized_pnl":"-55.76239701",
+						"total_session_realized_pnl":"0.00000000",
+						"total_effective_leverage":"1.90401230",
+						"position_limit":"3000000.00000000",
+						"used_position_limit":"40674.69622001",
+						"is_liquidating":false,
+						"position_balances":[
+							{
+								"instrument_name":"CRO",
+								"quantity":"24422.72427884",
+								"market_value":"4776.107959",
+								"collateral_amount":"4537.302561",
+								"haircut":"0.05",
+								"max_withdrawal_balance":"24422.72427884",
+								"reserved_qty":"0.00000000"
+							}
+						]
+					}
+				]
+			}
+		}`
+
+		_, err := w.Write([]byte(res))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id)
+	signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		ID:        id,
+		Method:    cdcexchange.MethodGetSubAccountBalances,
+		Timestamp: now.UnixMilli(),
+		Params:    map[string]interface{}{},
+	}).Return(signature, nil)
+
+	res, err := client.GetSubAccountBalances(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []cdcexchange.SubAccountBalance{
+		{
+			Account:                   "sub uuid",
+			InstrumentName:            "USD",
+			TotalAvailableBalance:     decimal.RequireFromString("4721.05898582"),
+			TotalMarginBalance:        decimal.RequireFromString("7595.42571782"),
+			TotalInitialMargin:        decimal.RequireFromString("2874.366732"),
+			TotalMaintenanceMargin:    decimal.RequireFromString("1437.183366"),
+			TotalPositionCost:         decimal.RequireFromString("14517.54641301"),
+			TotalCashBalance:          decimal.RequireFromString("7890.00320721"),
+			TotalCollateralValue:      decimal.RequireFromString("7651.18811483"),
+			TotalSessionUnrealizedPnl: decimal.RequireFromString("-55.76239701"),
+			TotalSessionRealizedPnl:   decimal.RequireFromString("0.00000000"),
+			TotalEffectiveLeverage:    decimal.RequireFromString("1.90401230"),
+			PositionLimit:             decimal.RequireFromString("3000000.00000000"),
+			UsedPositionLimit:         decimal.RequireFromString("40674.69622001"),
+			PositionBalances: []cdcexchange.PositionBalance{
+				{
+					InstrumentName:       "CRO",
+					Quantity:             decimal.RequireFromString("24422.72427884"),
+					MarketValue:          decimal.RequireFromString("4776.107959"),
+					CollateralAmount:     decimal.RequireFromString("4537.302561"),
+					Haircut:              decimal.RequireFromString("0.05"),
+					MaxWithdrawalBalance: decimal.RequireFromString("24422.72427884"),
+					ReservedQty:          decimal.RequireFromString("0.00000000"),
+				},
+			},
+		},
+	}, res)
+}
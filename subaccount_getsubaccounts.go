@@ -0,0 +1,125 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	methodGetSubAccounts = "private/subaccount/get-sub-accounts"
+)
+
+type (
+	// GetSubAccountsResponse is the base response returned from the private/subaccount/get-sub-accounts API.
+	GetSubAccountsResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetSubAccountsResult `json:"result"`
+	}
+
+	// GetSubAccountsResult is the result returned from the private/subaccount/get-sub-accounts API.
+	GetSubAccountsResult struct {
+		// SubAccountList is the array of sub-accounts.
+		SubAccountList []SubAccount `json:"sub_account_list"`
+	}
+
+	// SubAccount represents the details of a sub-account.
+	SubAccount struct {
+		// UUID is the unique identifier of the sub-account.
+		UUID string `json:"uuid"`
+		// MasterAccountUUID is the unique identifier of the master account.
+		MasterAccountUUID string `json:"master_account_uuid"`
+		// MarginAccountUUID is the unique identifier of the sub-account's margin account (if enabled).
+		MarginAccountUUID string `json:"margin_account_uuid"`
+		// Label is the label of the sub-account.
+		Label string `json:"label"`
+		// Enabled is whether the sub-account is enabled.
+		Enabled bool `json:"enabled"`
+		// Tradable is whether the sub-account can trade.
+		Tradable bool `json:"tradable"`
+		// Name is the name of the sub-account holder.
+		Name string `json:"name"`
+		// Email is the email address of the sub-account.
+		Email string `json:"email"`
+		// MobileNumber is the mobile number of the sub-account.
+		MobileNumber string `json:"mobile_number"`
+		// CountryCode is the country code of the sub-account.
+		CountryCode string `json:"country_code"`
+		// Address is the address of the sub-account holder.
+		Address string `json:"address"`
+		// MarginAccess is the margin access of the sub-account (e.g. DEFAULT or DISABLED).
+		MarginAccess string `json:"margin_access"`
+		// DerivativesAccess is the derivatives access of the sub-account (e.g. DEFAULT or DISABLED).
+		DerivativesAccess string `json:"derivatives_access"`
+		// CreateTime is the time the sub-account was created.
+		CreateTime cdctime.Time `json:"create_time"`
+		// UpdateTime is the time the sub-account was last updated.
+		UpdateTime cdctime.Time `json:"update_time"`
+		// TwoFAEnabled is whether two-factor authentication is enabled for the sub-account.
+		TwoFAEnabled bool `json:"two_fa_enabled"`
+		// KYCLevel is the KYC level of the sub-account.
+		KYCLevel string `json:"kyc_level"`
+		// Suspended is whether the sub-account is suspended.
+		Suspended bool `json:"suspended"`
+		// Terminated is whether the sub-account is terminated.
+		Terminated bool `json:"terminated"`
+	}
+)
+
+// GetSubAccounts gets the sub-accounts of the master account.
+//
+// Method: private/subaccount/get-sub-accounts
+func (c *Client) GetSubAccounts(ctx context.Context) ([]SubAccount, error) {
+	params := make(map[string]interface{})
+
+	var getSubAccountsResponse GetSubAccountsResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetSubAccounts,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetSubAccounts,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getSubAccountsResponse = GetSubAccountsResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetSubAccounts, &getSubAccountsResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getSubAccountsResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getSubAccountsResponse.Result.SubAccountList, nil
+}
@@ -0,0 +1,172 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	methodGetSubAccountTransferHistory = "private/subaccount/get-transfer-history"
+)
+
+type (
+	// GetSubAccountTransferHistoryRequest is the request params sent for the private/subaccount/get-transfer-history API.
+	//
+	// The maximum duration between Start and End is 24 hours.
+	//
+	// You will receive an INVALID_DATE_RANGE error if the difference exceeds the maximum duration.
+	GetSubAccountTransferHistoryRequest struct {
+		// SubAccountUUID is the UUID of the sub-account.
+		// if SubAccountUUID is omitted, transfers of all sub-accounts will be returned.
+		SubAccountUUID string `json:"sub_account_uuid"`
+		// Direction represents the direction of the transfers, relative to the sub-account.
+		// if Direction is omitted, transfers in both directions will be returned.
+		Direction TransferDirection `json:"direction"`
+		// Currency represents the currency symbol for the transfers (e.g. BTC or USDT).
+		// if Currency is omitted, all currencies will be returned.
+		Currency string `json:"currency"`
+		// Start is the start timestamp (milliseconds since the Unix epoch)
+		// (Default: 24 hours ago)
+		Start time.Time `json:"start_ts"`
+		// End is the end timestamp (milliseconds since the Unix epoch)
+		// (Default: now)
+		End time.Time `json:"end_ts"`
+		// PageSize represents maximum number of transfers returned (for pagination)
+		// (Default: 20, Max: 200)
+		// if PageSize is 0, it will be set as 20 by default.
+		PageSize int `json:"page_size"`
+		// Page represents the page number (for pagination)
+		// (0-based)
+		Page int `json:"page"`
+	}
+
+	// GetSubAccountTransferHistoryResponse is the base response returned from the private/subaccount/get-transfer-history API.
+	GetSubAccountTransferHistoryResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+		// Result is the response attributes of the endpoint.
+		Result GetSubAccountTransferHistoryResult `json:"result"`
+	}
+
+	// GetSubAccountTransferHistoryResult is the result returned from the private/subaccount/get-transfer-history API.
+	GetSubAccountTransferHistoryResult struct {
+		// TransferList is the array of transfers.
+		TransferList []SubAccountTransferRecord `json:"transfer_list"`
+	}
+
+	// SubAccountTransferRecord represents the details of a transfer between the master account & a sub-account.
+	SubAccountTransferRecord struct {
+		// Direction is the direction of the transfer, relative to the sub-account.
+		Direction TransferDirection `json:"direction"`
+		// Time is the time of the transfer.
+		Time cdctime.Time `json:"time"`
+		// Amount is the amount transferred.
+		Amount decimal.Decimal `json:"amount"`
+		// Status is the status of the transfer.
+		Status TransferStatus `json:"status"`
+		// Information is a description of the transfer.
+		Information string `json:"information"`
+		// Currency is the currency symbol of the transfer (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+	}
+)
+
+// GetSubAccountTransferHistory gets the history of transfers between the master account & sub-accounts.
+//
+// Pagination is handled using page size (Default: 20, Max: 200) & number (0-based).
+// If paging is used, enumerate each page (starting with 0) until an empty transfer_list array appears in the response.
+//
+// req.SubAccountUUID, req.Direction & req.Currency can be left blank to get transfers of all sub-accounts,
+// in both directions, for all currencies.
+//
+// Method: private/subaccount/get-transfer-history
+func (c *Client) GetSubAccountTransferHistory(ctx context.Context, req GetSubAccountTransferHistoryRequest) ([]SubAccountTransferRecord, error) {
+	if req.Direction != "" && req.Direction != TransferDirectionIn && req.Direction != TransferDirectionOut {
+		return nil, errors.InvalidParameterError{Parameter: "req.Direction", Reason: "must be IN or OUT"}
+	}
+	if req.PageSize < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be less than 0"}
+	}
+	if req.PageSize > 200 {
+		return nil, errors.InvalidParameterError{Parameter: "req.PageSize", Reason: "cannot be greater than 200"}
+	}
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.InvalidParameterError{Parameter: "req.End", Reason: "cannot be before req.Start"}
+	}
+
+	params := make(map[string]interface{})
+
+	if req.SubAccountUUID != "" {
+		params["sub_account_uuid"] = req.SubAccountUUID
+	}
+	if req.Direction != "" {
+		params["direction"] = req.Direction
+	}
+	if req.Currency != "" {
+		params["currency"] = req.Currency
+	}
+	if req.PageSize != 0 {
+		params["page_size"] = req.PageSize
+	}
+	if !req.Start.IsZero() {
+		params["start_ts"] = req.Start.UnixMilli()
+	}
+	if !req.End.IsZero() {
+		params["end_ts"] = req.End.UnixMilli()
+	}
+	params["page"] = req.Page
+
+	var getSubAccountTransferHistoryResponse GetSubAccountTransferHistoryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodGetSubAccountTransferHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodGetSubAccountTransferHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		getSubAccountTransferHistoryResponse = GetSubAccountTransferHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodGetSubAccountTransferHistory, &getSubAccountTransferHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, getSubAccountTransferHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getSubAccountTransferHistoryResponse.Result.TransferList, nil
+}
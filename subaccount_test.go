@@ -0,0 +1,145 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+)
+
+// subAccountServer serves responses[method] for each method requested.
+// It returns a Client which sends requests to the server, and a func returning each request sent.
+func subAccountServer(t *testing.T, responses map[string]string) (*cdcexchange.Client, func() []api.Request) {
+	s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		res, ok := responses[req.Method]
+		require.True(t, ok, "unexpected method: %s", req.Method)
+
+		return res
+	})
+
+	client, err := cdcexchange.New("api key", "secret key",
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+	)
+	require.NoError(t, err)
+
+	return client, requests
+}
+
+func TestSubAccountClient_Transfer(t *testing.T) {
+	const (
+		subAccountUUID = "sub uuid"
+		masterUUID     = "master uuid"
+	)
+	ctx := context.Background()
+
+	t.Run("transfers between the master account & the sub-account", func(t *testing.T) {
+		client, requests := subAccountServer(t, map[string]string{
+			cdcexchange.MethodGetSubAccounts: `{"id":0,"method":"","code":0,"result":{"sub_account_list":[
+				{"uuid":"other uuid","master_account_uuid":"master uuid"},
+				{"uuid":"sub uuid","master_account_uuid":"master uuid"}
+			]}}`,
+			cdcexchange.MethodSubAccountTransfer: `{"id":0,"method":"","code":0}`,
+		})
+
+		sub := client.SubAccount(subAccountUUID)
+		assert.Equal(t, subAccountUUID, sub.UUID())
+
+		require.NoError(t, sub.TransferIn(ctx, "USDT", decimal.NewFromInt(100)))
+		require.NoError(t, sub.TransferOut(ctx, "USDT", decimal.NewFromInt(50)))
+
+		// the master account is only looked up once.
+		reqs := requests()
+		require.Len(t, reqs, 3)
+		assert.Equal(t, cdcexchange.MethodGetSubAccounts, reqs[0].Method)
+
+		assert.Equal(t, cdcexchange.MethodSubAccountTransfer, reqs[1].Method)
+		assert.Equal(t, masterUUID, reqs[1].Params["from"])
+		assert.Equal(t, subAccountUUID, reqs[1].Params["to"])
+		assert.Equal(t, "100", reqs[1].Params["amount"])
+
+		assert.Equal(t, cdcexchange.MethodSubAccountTransfer, reqs[2].Method)
+		assert.Equal(t, subAccountUUID, reqs[2].Params["from"])
+		assert.Equal(t, masterUUID, reqs[2].Params["to"])
+		assert.Equal(t, "50", reqs[2].Params["amount"])
+	})
+
+	t.Run("returns error when sub-account does not exist", func(t *testing.T) {
+		client, requests := subAccountServer(t, map[string]string{
+			cdcexchange.MethodGetSubAccounts: `{"id":0,"method":"","code":0,"result":{"sub_account_list":[
+				{"uuid":"other uuid","master_account_uuid":"master uuid"}
+			]}}`,
+		})
+
+		err := client.SubAccount(subAccountUUID).TransferIn(ctx, "USDT", decimal.NewFromInt(100))
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, cdcerrors.ErrSubAccountNotFound))
+		assert.Len(t, requests(), 1)
+	})
+
+	t.Run("returns error when uuid is empty", func(t *testing.T) {
+		client, requests := subAccountServer(t, nil)
+
+		err := client.SubAccount("").TransferOut(ctx, "USDT", decimal.NewFromInt(100))
+		require.Error(t, err)
+
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "uuid", Reason: "cannot be empty"}, err)
+		assert.Empty(t, requests())
+	})
+}
+
+func TestSubAccountClient_GetBalance(t *testing.T) {
+	ctx := context.Background()
+
+	client, requests := subAccountServer(t, map[string]string{
+		cdcexchange.MethodGetSubAccountBalances: `{"id":0,"method":"","code":0,"result":{"data":[
+			{"account":"other uuid","total_cash_balance":"1"},
+			{"account":"sub uuid","total_cash_balance":"2"}
+		]}}`,
+	})
+
+	balance, err := client.SubAccount("sub uuid").GetBalance(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub uuid", balance.Account)
+	assert.Equal(t, "2", balance.TotalCashBalance.String())
+
+	balance, err = client.SubAccount("unknown uuid").GetBalance(ctx)
+	require.Error(t, err)
+
+	assert.Nil(t, balance)
+	assert.True(t, errors.Is(err, cdcerrors.ErrSubAccountNotFound))
+	assert.Len(t, requests(), 2)
+}
+
+func TestSubAccountClient_GetTransferHistory(t *testing.T) {
+	client, requests := subAccountServer(t, map[string]string{
+		cdcexchange.MethodGetSubAccountTransferHistory: `{"id":0,"method":"","code":0,"result":{"transfer_list":[
+			{"direction":"IN","amount":100,"status":"COMPLETED","currency":"USDT"}
+		]}}`,
+	})
+
+	transfers, err := client.SubAccount("sub uuid").GetTransferHistory(context.Background(), cdcexchange.GetSubAccountTransferHistoryRequest{
+		SubAccountUUID: "other uuid",
+		Currency:       "USDT",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, transfers, 1)
+	assert.Equal(t, cdcexchange.TransferStatusCompleted, transfers[0].Status)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "sub uuid", reqs[0].Params["sub_account_uuid"])
+	assert.Equal(t, "USDT", reqs[0].Params["currency"])
+}
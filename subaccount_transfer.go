@@ -0,0 +1,110 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+)
+
+const (
+	methodSubAccountTransfer = "private/subaccount/transfer"
+)
+
+type (
+	// SubAccountTransferRequest is the request params sent for the private/subaccount/transfer API.
+	SubAccountTransferRequest struct {
+		// From is the UUID of the account the funds are transferred from (master or sub-account).
+		From string `json:"from"`
+		// To is the UUID of the account the funds are transferred to (master or sub-account).
+		To string `json:"to"`
+		// Currency is the currency symbol to transfer (e.g. BTC or USDT).
+		Currency string `json:"currency"`
+		// Amount is the amount to transfer.
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	// SubAccountTransferResponse is the base response returned from the private/subaccount/transfer API.
+	SubAccountTransferResponse struct {
+		// api.BaseResponse is the common response fields.
+		api.BaseResponse
+	}
+)
+
+// SubAccountTransfer transfers funds between the master account & a sub-account, or between sub-accounts.
+//
+// Method: private/subaccount/transfer
+func (c *Client) SubAccountTransfer(ctx context.Context, req SubAccountTransferRequest) error {
+	if req.From == "" {
+		return errors.InvalidParameterError{Parameter: "req.From", Reason: "cannot be empty"}
+	}
+	if req.To == "" {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be empty"}
+	}
+	if req.From == req.To {
+		return errors.InvalidParameterError{Parameter: "req.To", Reason: "cannot be the same as req.From"}
+	}
+	if req.Currency == "" {
+		return errors.InvalidParameterError{Parameter: "req.Currency", Reason: "cannot be empty"}
+	}
+	if !req.Amount.IsPositive() {
+		return errors.InvalidParameterError{Parameter: "req.Amount", Reason: "must be greater than 0"}
+	}
+
+	params := make(map[string]interface{})
+
+	params["from"] = req.From
+	params["to"] = req.To
+	params["currency"] = req.Currency
+	params["amount"] = req.Amount.String()
+
+	var subAccountTransferResponse SubAccountTransferResponse
+	err := c.retry(ctx, false, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
+
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodSubAccountTransfer,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
+
+		body := api.Request{
+			ID:        id,
+			Method:    methodSubAccountTransfer,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+		}
+
+		subAccountTransferResponse = SubAccountTransferResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodSubAccountTransfer, &subAccountTransferResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, subAccountTransferResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -5,24 +5,42 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sngyai/go-cryptocom/errors"
 	"github.com/sngyai/go-cryptocom/internal/api"
 	"github.com/sngyai/go-cryptocom/internal/auth"
 )
 
 const (
 	methodUserBalanceHistory = "private/user-balance-history"
+
+	// UserBalanceTimeframeHourly returns the balance at the end of every hour.
+	UserBalanceTimeframeHourly = "H1"
+	// UserBalanceTimeframeDaily returns the balance at the end of every day.
+	UserBalanceTimeframeDaily = "D1"
+
+	maxUserBalanceHourlyLimit = 120
+	maxUserBalanceDailyLimit  = 30
 )
 
 type (
+	// UserBalance is the total balance of the account at a point in time.
 	UserBalance struct {
-		T int64  `json:"t"`
+		// T is the timestamp of the balance (milliseconds since the Unix epoch).
+		T int64 `json:"t"`
+		// C is the total cash balance.
 		C string `json:"c"`
 	}
 	// UserBalanceHistoryRequest is the request params sent for the private/user-balance-history API.
 	UserBalanceHistoryRequest struct {
-		Timeframe string    `json:"timeframe"`
-		EndTime   time.Time `json:"end_time"`
-		Limit     int       `json:"limit"`
+		// Timeframe represents the interval between balances (UserBalanceTimeframeHourly or UserBalanceTimeframeDaily).
+		// (Default: UserBalanceTimeframeDaily)
+		Timeframe string `json:"timeframe"`
+		// EndTime is the timestamp of the last balance returned.
+		// (Default: now)
+		EndTime time.Time `json:"end_time"`
+		// Limit represents the maximum number of balances returned.
+		// (Max: 120 for UserBalanceTimeframeHourly, 30 for UserBalanceTimeframeDaily)
+		Limit int `json:"limit"`
 	}
 
 	// UserBalanceHistoryResponse is the base response returned from the private/user-balance-history API.
@@ -40,14 +58,26 @@ type (
 	}
 )
 
-// UserBalanceHistory gets all executed trades for a particular instrument.
+// UserBalanceHistory gets the history of the total balance of the account.
+//
 // Method: private/user-balance-history
 func (c *Client) UserBalanceHistory(ctx context.Context, req UserBalanceHistoryRequest) (*UserBalanceHistoryResult, error) {
-	var (
-		id        = c.idGenerator.Generate()
-		timestamp = c.clock.Now().UnixMilli()
-		params    = make(map[string]interface{})
-	)
+	maxLimit := maxUserBalanceDailyLimit
+	switch req.Timeframe {
+	case "", UserBalanceTimeframeDaily:
+	case UserBalanceTimeframeHourly:
+		maxLimit = maxUserBalanceHourlyLimit
+	default:
+		return nil, errors.InvalidParameterError{Parameter: "req.Timeframe", Reason: "must be H1 or D1"}
+	}
+	if req.Limit < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: "cannot be less than 0"}
+	}
+	if req.Limit > maxLimit {
+		return nil, errors.InvalidParameterError{Parameter: "req.Limit", Reason: fmt.Sprintf("cannot be greater than %d", maxLimit)}
+	}
+
+	params := make(map[string]interface{})
 
 	if req.Timeframe != "" {
 		params["timeframe"] = req.Timeframe
@@ -59,36 +89,49 @@ func (c *Client) UserBalanceHistory(ctx context.Context, req UserBalanceHistoryR
 		params["end_time"] = req.EndTime.UnixMilli()
 	}
 
-	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
-		APIKey:    c.apiKey,
-		SecretKey: c.secretKey,
-		ID:        id,
-		Method:    methodUserBalanceHistory,
-		Timestamp: timestamp,
-		Params:    params,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signature: %w", err)
-	}
+	var userBalanceHistoryResponse UserBalanceHistoryResponse
+	err := c.retry(ctx, true, func() error {
+		var (
+			id        = c.idGenerator.Generate()
+			timestamp = c.nonce()
+		)
 
-	body := api.Request{
-		ID:        id,
-		Method:    methodUserBalanceHistory,
-		Nonce:     timestamp,
-		Params:    params,
-		Signature: signature,
-		APIKey:    c.apiKey,
-		Version:   api.V1,
-	}
+		signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+			APIKey:    c.apiKey,
+			SecretKey: c.secretKey,
+			ID:        id,
+			Method:    methodUserBalanceHistory,
+			Timestamp: timestamp,
+			Params:    params,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create signature: %w", err)
+		}
 
-	var userBalanceHistoryResponse UserBalanceHistoryResponse
-	statusCode, err := c.requester.Post(ctx, body, methodUserBalanceHistory, &userBalanceHistoryResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute post request: %w", err)
-	}
+		body := api.Request{
+			ID:        id,
+			Method:    methodUserBalanceHistory,
+			Nonce:     timestamp,
+			Params:    params,
+			Signature: signature,
+			APIKey:    c.apiKey,
+			Version:   api.V1,
+		}
 
-	if err := c.requester.CheckErrorResponse(statusCode, userBalanceHistoryResponse.Code); err != nil {
-		return nil, fmt.Errorf("error received in response: %w", err)
+		userBalanceHistoryResponse = UserBalanceHistoryResponse{}
+		statusCode, err := c.requester.Post(ctx, body, methodUserBalanceHistory, &userBalanceHistoryResponse)
+		if err != nil {
+			return fmt.Errorf("failed to execute post request: %w", err)
+		}
+
+		if err := c.requester.CheckErrorResponse(statusCode, userBalanceHistoryResponse.Code); err != nil {
+			return fmt.Errorf("error received in response: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &userBalanceHistoryResponse.Result, nil
@@ -0,0 +1,204 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// WithOrderValidation will initialise the Client to validate orders against the instrument's metadata before
+// they are sent (see CreateOrderRequest.Validate), so that invalid orders are rejected without a round trip.
+//
+// Instruments are looked up in the Client's InstrumentRegistry.
+func WithOrderValidation() ClientOption {
+	return func(c *Client) error {
+		c.validateOrders = true
+		return nil
+	}
+}
+
+// Validate checks the request against the mandatory parameters of its order type and the limits of instrument.
+//
+// The returned error is an errors.ValidationError wrapping the error the API would have responded with
+// (e.g. errors.ErrMinQuantityViolated), so it can be checked with errors.Is.
+func (r CreateOrderRequest) Validate(instrument Instrument) error {
+	if err := r.validateMandatory(); err != nil {
+		return err
+	}
+
+	if r.InstrumentName != instrument.InstrumentName {
+		return errors.InvalidParameterError{Parameter: "instrument", Reason: "must match req.InstrumentName"}
+	}
+
+	priceLimits := decimalLimits{
+		min:      instrument.MinPrice,
+		max:      instrument.MaxPrice,
+		tickSize: instrument.PriceTickSize,
+		decimals: instrument.PriceDecimals,
+		minErr:   errors.ErrMinPriceViolated,
+		maxErr:   errors.ErrMaxPriceViolated,
+		precErr:  errors.ErrInvalidPricePrecision,
+	}
+	quantityLimits := decimalLimits{
+		min:      instrument.MinQuantity,
+		max:      instrument.MaxQuantity,
+		tickSize: instrument.QuantityTickSize,
+		decimals: instrument.QuantityDecimals,
+		minErr:   errors.ErrMinQuantityViolated,
+		maxErr:   errors.ErrMaxQuantityViolated,
+		precErr:  errors.ErrInvalidQuantityPrecision,
+	}
+
+	if !r.Price.IsZero() {
+		if err := priceLimits.validate("req.Price", r.Price); err != nil {
+			return err
+		}
+	}
+	if !r.TriggerPrice.IsZero() {
+		if err := priceLimits.validate("req.TriggerPrice", r.TriggerPrice); err != nil {
+			return err
+		}
+	}
+	if !r.Quantity.IsZero() {
+		if err := quantityLimits.validate("req.Quantity", r.Quantity); err != nil {
+			return err
+		}
+	}
+	if r.Notional.IsNegative() {
+		return errors.ValidationError{Parameter: "req.Notional", Reason: "cannot be negative", Err: errors.ErrMinNotionalViolated}
+	}
+
+	return nil
+}
+
+// validateMandatory checks the mandatory parameters for the order type & side documented on CreateOrderRequest.
+func (r CreateOrderRequest) validateMandatory() error {
+	switch {
+	case r.InstrumentName == "":
+		return errors.ValidationError{Parameter: "req.InstrumentName", Reason: "cannot be empty", Err: errors.ErrMissingArgument}
+	case r.Side == "":
+		return errors.ValidationError{Parameter: "req.Side", Reason: "cannot be empty", Err: errors.ErrMissingArgument}
+	case r.Side != OrderSideBuy && r.Side != OrderSideSell:
+		return errors.ValidationError{Parameter: "req.Side", Reason: fmt.Sprintf("%s is not supported", r.Side), Err: errors.ErrSideNotSupported}
+	case r.Type == "":
+		return errors.ValidationError{Parameter: "req.Type", Reason: "cannot be empty", Err: errors.ErrMissingArgument}
+	}
+
+	var (
+		price        = !r.Price.IsZero()
+		quantity     = !r.Quantity.IsZero()
+		notional     = !r.Notional.IsZero()
+		triggerPrice = !r.TriggerPrice.IsZero()
+		buy          = r.Side == OrderSideBuy
+	)
+
+	switch r.Type {
+	case OrderTypeLimit:
+		return requireFields(r.Type, r.Side, orderField{"req.Quantity", quantity}, orderField{"req.Price", price})
+	case OrderTypeMarket:
+		if buy {
+			if quantity && notional {
+				return errors.ValidationError{Parameter: "req.Notional", Reason: "cannot be set with req.Quantity for MARKET BUY orders", Err: errors.ErrBadRequest}
+			}
+			if !quantity && !notional {
+				return errors.ValidationError{Parameter: "req.Quantity", Reason: "or req.Notional must be set for MARKET BUY orders", Err: errors.ErrMissingArgument}
+			}
+			return nil
+		}
+		return requireFields(r.Type, r.Side, orderField{"req.Quantity", quantity})
+	case OrderTypeStopLimit, OrderTypeTakeProfitLimit:
+		return requireFields(r.Type, r.Side, orderField{"req.Price", price}, orderField{"req.Quantity", quantity}, orderField{"req.TriggerPrice", triggerPrice})
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		if buy {
+			return requireFields(r.Type, r.Side, orderField{"req.Notional", notional}, orderField{"req.TriggerPrice", triggerPrice})
+		}
+		return requireFields(r.Type, r.Side, orderField{"req.Quantity", quantity}, orderField{"req.TriggerPrice", triggerPrice})
+	default:
+		return errors.ValidationError{Parameter: "req.Type", Reason: fmt.Sprintf("%s is not supported", r.Type), Err: errors.ErrOrderTypeNotSupported}
+	}
+}
+
+// orderField is a parameter of CreateOrderRequest and whether it has been set.
+type orderField struct {
+	name string
+	set  bool
+}
+
+// requireFields returns an error for the first field which is not set.
+func requireFields(orderType OrderType, side OrderSide, fields ...orderField) error {
+	for _, field := range fields {
+		if !field.set {
+			return errors.ValidationError{
+				Parameter: field.name,
+				Reason:    fmt.Sprintf("must be set for %s %s orders", orderType, side),
+				Err:       errors.ErrMissingArgument,
+			}
+		}
+	}
+
+	return nil
+}
+
+// decimalLimits are an instrument's limits for a price or quantity, zero values are not checked
+// (as instruments may not have every limit, e.g. 0 decimals is taken to mean the precision is only set by the tick size).
+type decimalLimits struct {
+	min      decimal.Decimal
+	max      decimal.Decimal
+	tickSize decimal.Decimal
+	decimals int
+
+	minErr  error
+	maxErr  error
+	precErr error
+}
+
+func (l decimalLimits) validate(parameter string, value decimal.Decimal) error {
+	switch {
+	case value.IsNegative():
+		return errors.ValidationError{Parameter: parameter, Reason: "cannot be negative", Err: l.minErr}
+	case l.min.IsPositive() && value.LessThan(l.min):
+		return errors.ValidationError{Parameter: parameter, Reason: fmt.Sprintf("cannot be less than %s", l.min), Err: l.minErr}
+	case l.max.IsPositive() && value.GreaterThan(l.max):
+		return errors.ValidationError{Parameter: parameter, Reason: fmt.Sprintf("cannot be greater than %s", l.max), Err: l.maxErr}
+	case l.decimals > 0 && decimalPlaces(value) > l.decimals:
+		return errors.ValidationError{Parameter: parameter, Reason: fmt.Sprintf("cannot have more than %d decimal places", l.decimals), Err: l.precErr}
+	case l.tickSize.IsPositive() && !value.Mod(l.tickSize).IsZero():
+		return errors.ValidationError{Parameter: parameter, Reason: fmt.Sprintf("must be a multiple of %s", l.tickSize), Err: l.precErr}
+	}
+
+	return nil
+}
+
+// decimalPlaces returns the number of significant decimal places of d (e.g. 1.2300 has 2).
+func decimalPlaces(d decimal.Decimal) int {
+	s := d.String()
+
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return 0
+	}
+
+	return len(s) - i - 1
+}
+
+// validateOrder validates req against its instrument if order validation is enabled.
+func (c *Client) validateOrder(ctx context.Context, req CreateOrderRequest) error {
+	if !c.validateOrders {
+		return nil
+	}
+
+	if err := req.validateMandatory(); err != nil {
+		return err
+	}
+
+	instrument, err := c.instruments.Instrument(ctx, req.InstrumentName)
+	if err != nil {
+		return err
+	}
+
+	return req.Validate(instrument)
+}
@@ -0,0 +1,295 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+)
+
+var validationInstrument = cdcexchange.Instrument{
+	InstrumentName:   "BTC_USDT",
+	QuoteCurrency:    "USDT",
+	BaseCurrency:     "BTC",
+	PriceDecimals:    2,
+	QuantityDecimals: 4,
+	MinPrice:         decimal.RequireFromString("1"),
+	MaxPrice:         decimal.RequireFromString("100000"),
+	MinQuantity:      decimal.RequireFromString("0.001"),
+	MaxQuantity:      decimal.RequireFromString("100"),
+	PriceTickSize:    decimal.RequireFromString("0.05"),
+	QuantityTickSize: decimal.RequireFromString("0.0005"),
+}
+
+func TestCreateOrderRequest_Validate(t *testing.T) {
+	limit := func(price string, quantity string) cdcexchange.CreateOrderRequest {
+		return cdcexchange.CreateOrderRequest{
+			InstrumentName: "BTC_USDT",
+			Side:           cdcexchange.OrderSideBuy,
+			Type:           cdcexchange.OrderTypeLimit,
+			Price:          decimal.RequireFromString(price),
+			Quantity:       decimal.RequireFromString(quantity),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		req         cdcexchange.CreateOrderRequest
+		expectedErr error
+	}{
+		{
+			name: "returns nil given valid LIMIT order",
+			req:  limit("100.05", "1.0005"),
+		},
+		{
+			name: "returns nil given valid MARKET BUY order with notional",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeMarket,
+				Notional:       decimal.RequireFromString("10.123"),
+			},
+		},
+		{
+			name: "returns nil given valid STOP_LOSS SELL order",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideSell,
+				Type:           cdcexchange.OrderTypeStopLoss,
+				Quantity:       decimal.RequireFromString("1"),
+				TriggerPrice:   decimal.RequireFromString("90"),
+			},
+		},
+		{
+			name:        "returns error when instrument name is empty",
+			req:         cdcexchange.CreateOrderRequest{Side: cdcexchange.OrderSideBuy, Type: cdcexchange.OrderTypeLimit},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.InstrumentName", Reason: "cannot be empty", Err: cdcerrors.ErrMissingArgument},
+		},
+		{
+			name:        "returns error when side is not supported",
+			req:         cdcexchange.CreateOrderRequest{InstrumentName: "BTC_USDT", Side: "HOLD", Type: cdcexchange.OrderTypeLimit},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Side", Reason: "HOLD is not supported", Err: cdcerrors.ErrSideNotSupported},
+		},
+		{
+			name:        "returns error when type is not supported",
+			req:         cdcexchange.CreateOrderRequest{InstrumentName: "BTC_USDT", Side: cdcexchange.OrderSideBuy, Type: "ICEBERG"},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Type", Reason: "ICEBERG is not supported", Err: cdcerrors.ErrOrderTypeNotSupported},
+		},
+		{
+			name: "returns error when LIMIT order has no price",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideSell,
+				Type:           cdcexchange.OrderTypeLimit,
+				Quantity:       decimal.RequireFromString("1"),
+			},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Price", Reason: "must be set for LIMIT SELL orders", Err: cdcerrors.ErrMissingArgument},
+		},
+		{
+			name: "returns error when MARKET BUY order has quantity and notional",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeMarket,
+				Quantity:       decimal.RequireFromString("1"),
+				Notional:       decimal.RequireFromString("10"),
+			},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Notional", Reason: "cannot be set with req.Quantity for MARKET BUY orders", Err: cdcerrors.ErrBadRequest},
+		},
+		{
+			name: "returns error when TAKE_PROFIT BUY order has no notional",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeTakeProfit,
+				Quantity:       decimal.RequireFromString("1"),
+				TriggerPrice:   decimal.RequireFromString("110"),
+			},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Notional", Reason: "must be set for TAKE_PROFIT BUY orders", Err: cdcerrors.ErrMissingArgument},
+		},
+		{
+			name: "returns error when STOP_LIMIT order has no trigger price",
+			req: cdcexchange.CreateOrderRequest{
+				InstrumentName: "BTC_USDT",
+				Side:           cdcexchange.OrderSideBuy,
+				Type:           cdcexchange.OrderTypeStopLimit,
+				Price:          decimal.RequireFromString("100"),
+				Quantity:       decimal.RequireFromString("1"),
+			},
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.TriggerPrice", Reason: "must be set for STOP_LIMIT BUY orders", Err: cdcerrors.ErrMissingArgument},
+		},
+		{
+			name:        "returns error when price is less than the minimum",
+			req:         limit("0.5", "1"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Price", Reason: "cannot be less than 1", Err: cdcerrors.ErrMinPriceViolated},
+		},
+		{
+			name:        "returns error when price is greater than the maximum",
+			req:         limit("100000.05", "1"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Price", Reason: "cannot be greater than 100000", Err: cdcerrors.ErrMaxPriceViolated},
+		},
+		{
+			name:        "returns error when price has too many decimal places",
+			req:         limit("100.001", "1"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Price", Reason: "cannot have more than 2 decimal places", Err: cdcerrors.ErrInvalidPricePrecision},
+		},
+		{
+			name:        "returns error when price is not a multiple of the tick size",
+			req:         limit("100.01", "1"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Price", Reason: "must be a multiple of 0.05", Err: cdcerrors.ErrInvalidPricePrecision},
+		},
+		{
+			name:        "returns error when quantity is less than the minimum",
+			req:         limit("100", "0.0005"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Quantity", Reason: "cannot be less than 0.001", Err: cdcerrors.ErrMinQuantityViolated},
+		},
+		{
+			name:        "returns error when quantity is greater than the maximum",
+			req:         limit("100", "101"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Quantity", Reason: "cannot be greater than 100", Err: cdcerrors.ErrMaxQuantityViolated},
+		},
+		{
+			name:        "returns error when quantity is not a multiple of the tick size",
+			req:         limit("100", "1.0001"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Quantity", Reason: "must be a multiple of 0.0005", Err: cdcerrors.ErrInvalidQuantityPrecision},
+		},
+		{
+			name:        "returns error when quantity is negative",
+			req:         limit("100", "-1"),
+			expectedErr: cdcerrors.ValidationError{Parameter: "req.Quantity", Reason: "cannot be negative", Err: cdcerrors.ErrMinQuantityViolated},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(validationInstrument)
+			assert.Equal(t, tt.expectedErr, err)
+
+			var validationErr cdcerrors.ValidationError
+			if errors.As(tt.expectedErr, &validationErr) {
+				assert.True(t, errors.Is(err, validationErr.Err))
+			}
+		})
+	}
+
+	t.Run("returns error when instrument does not match", func(t *testing.T) {
+		req := limit("100", "1")
+		req.InstrumentName = "ETH_USDT"
+
+		err := req.Validate(validationInstrument)
+		assert.Equal(t, cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "must match req.InstrumentName"}, err)
+	})
+
+	t.Run("does not check decimal places of instrument without decimals", func(t *testing.T) {
+		instrument := validationInstrument
+		instrument.PriceDecimals = 0
+		instrument.PriceTickSize = decimal.Decimal{}
+
+		assert.NoError(t, limit("100.001", "1").Validate(instrument))
+	})
+
+	t.Run("checks tick size of instrument without decimals", func(t *testing.T) {
+		instrument := validationInstrument
+		instrument.PriceDecimals = 0
+		instrument.PriceTickSize = decimal.RequireFromString("1")
+
+		err := limit("100.5", "1").Validate(instrument)
+		assert.Equal(t, cdcerrors.ValidationError{Parameter: "req.Price", Reason: "must be a multiple of 1", Err: cdcerrors.ErrInvalidPricePrecision}, err)
+	})
+}
+
+func TestClient_CreateOrder_Validation(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	now := time.Now()
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+		instrumentCalls    int32
+		orderCalls         int32
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, cdcexchange.MethodGetInstruments) {
+			atomic.AddInt32(&instrumentCalls, 1)
+			require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.InstrumentsResponse{
+				Result: cdcexchange.InstrumentResult{Instruments: []cdcexchange.Instrument{validationInstrument}},
+			}))
+			return
+		}
+
+		assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateOrder)
+		atomic.AddInt32(&orderCalls, 1)
+		require.NoError(t, json.NewEncoder(w).Encode(cdcexchange.CreateOrderResponse{
+			BaseResponse: api.BaseResponse{},
+			Result:       cdcexchange.CreateOrderResult{OrderID: "1"},
+		}))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithHTTPClient(s.Client()),
+		cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		cdcexchange.WithOrderValidation(),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+	signatureGenerator.EXPECT().GenerateSignature(gomock.Any()).Return(signature, nil)
+
+	req := cdcexchange.CreateOrderRequest{
+		InstrumentName: "BTC_USDT",
+		Side:           cdcexchange.OrderSideBuy,
+		Type:           cdcexchange.OrderTypeLimit,
+		Price:          decimal.RequireFromString("100.01"),
+		Quantity:       decimal.RequireFromString("1"),
+	}
+
+	res, err := client.CreateOrder(ctx, req)
+	require.Error(t, err)
+
+	assert.Nil(t, res)
+	assert.True(t, errors.Is(err, cdcerrors.ErrInvalidPricePrecision))
+
+	req.InstrumentName = "ETH_USDT"
+	_, err = client.CreateOrder(ctx, req)
+	assert.True(t, errors.Is(err, cdcerrors.ErrSymbolNotFound))
+
+	req.InstrumentName = "BTC_USDT"
+	req.Price = decimal.RequireFromString("100.05")
+	res, err = client.CreateOrder(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", res.OrderID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&instrumentCalls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&orderCalls))
+}
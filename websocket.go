@@ -0,0 +1,353 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+const (
+	uatSandboxMarketWebsocketURL = "wss://uat-stream.3ona.co/v2/market"
+	productionMarketWebsocketURL = "wss://stream.crypto.com/v2/market"
+	uatSandboxUserWebsocketURL   = "wss://uat-stream.3ona.co/v2/user"
+	productionUserWebsocketURL   = "wss://stream.crypto.com/v2/user"
+
+	// streamBufferSize is the buffer size of every channel returned from a subscription.
+	// Consumers should drain channels promptly, a full channel blocks the websocket read loop.
+	streamBufferSize = 64
+
+	StreamMarket StreamName = "market"
+	StreamUser   StreamName = "user"
+)
+
+type (
+	// StreamName identifies one of the Exchange websockets (market or user).
+	StreamName string
+
+	// stream manages a single websocket connection and the subscriptions made over it.
+	// The connection is dialled lazily on the first subscription.
+	stream struct {
+		client *Client
+		name   StreamName
+		url    string
+		dialer *websocket.Dialer
+		// authenticate is called on every new connection before it is used (user streams only).
+		authenticate func(ctx context.Context, conn *ws.Conn) error
+
+		// dialMu serialises dialling so that only a single connection is open at once.
+		dialMu sync.Mutex
+
+		mu   sync.Mutex
+		conn *ws.Conn
+		subs map[string][]*subscription
+		// cancelReconnect stops an in progress reconnect, it is nil when not reconnecting.
+		cancelReconnect context.CancelFunc
+		// reconnects is incremented on every reconnect, identifying the one cancelReconnect belongs to.
+		reconnects uint64
+		// attempts is the number of reconnect attempts since the connection was last resynced.
+		attempts int
+	}
+
+	// subscription is a single consumer of a websocket channel.
+	subscription struct {
+		mu      sync.Mutex
+		once    sync.Once
+		done    chan struct{}
+		handle  func(data json.RawMessage, done <-chan struct{}) error
+		onClose func()
+	}
+)
+
+func newStream(client *Client, name StreamName, url string, authenticate func(ctx context.Context, conn *ws.Conn) error) *stream {
+	return &stream{
+		client:       client,
+		name:         name,
+		url:          url,
+		dialer:       websocket.DefaultDialer,
+		authenticate: authenticate,
+		subs:         make(map[string][]*subscription),
+	}
+}
+
+// Close closes any open websocket connections.
+//
+// All channels returned from subscriptions will be closed.
+func (c *Client) Close() error {
+	marketErr := c.market.close()
+	userErr := c.user.close()
+
+	if marketErr != nil {
+		return marketErr
+	}
+
+	return userErr
+}
+
+// subscribe subscribes to channel, calling handle with the data of every message received.
+// The done channel passed to handle is closed once ctx is done or the stream is closed,
+// after which onClose is called and handle is no longer called.
+func (s *stream) subscribe(ctx context.Context, channel string, handle func(data json.RawMessage, done <-chan struct{}) error, onClose func()) error {
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	sub := &subscription{
+		done:    make(chan struct{}),
+		handle:  handle,
+		onClose: onClose,
+	}
+
+	s.mu.Lock()
+	s.subs[channel] = append(s.subs[channel], sub)
+	s.mu.Unlock()
+
+	if _, err := conn.Call(ctx, s.request(ws.MethodSubscribe, map[string]interface{}{
+		"channels": []string{channel},
+	})); err != nil {
+		s.remove(channel, sub)
+		return fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.unsubscribe(channel, sub)
+		case <-sub.done:
+		}
+	}()
+
+	return nil
+}
+
+// subscribeChan subscribes to channel, sending every item of every message received to ch (a chan of any item type),
+// which is closed once ctx is done or the stream is closed.
+//
+// decode converts the data of a message to a slice of items, it can be nil to decode the data as a JSON array of
+// the item type of ch.
+func (s *stream) subscribeChan(ctx context.Context, channel string, ch interface{}, decode func(data json.RawMessage) (interface{}, error)) error {
+	chv := reflect.ValueOf(ch)
+	if decode == nil {
+		sliceType := reflect.SliceOf(chv.Type().Elem())
+		decode = func(data json.RawMessage) (interface{}, error) {
+			items := reflect.New(sliceType)
+			if err := json.Unmarshal(data, items.Interface()); err != nil {
+				return nil, err
+			}
+			return items.Elem().Interface(), nil
+		}
+	}
+
+	return s.subscribe(ctx, channel, func(data json.RawMessage, done <-chan struct{}) error {
+		items, err := decode(data)
+		if err != nil {
+			return err
+		}
+
+		itemsv := reflect.ValueOf(items)
+		for i := 0; i < itemsv.Len(); i++ {
+			chosen, _, _ := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: chv, Send: itemsv.Index(i)},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+			})
+			if chosen == 1 {
+				return nil
+			}
+		}
+
+		return nil
+	}, chv.Close)
+}
+
+// connect returns the open connection, dialling (and authenticating) a new one if required.
+func (s *stream) connect(ctx context.Context) (*ws.Conn, error) {
+	s.dialMu.Lock()
+	defer s.dialMu.Unlock()
+
+	if conn := s.current(); conn != nil {
+		return conn, nil
+	}
+
+	conn, err := ws.Dial(ctx, s.dialer, s.url, s.dispatch)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.authenticate != nil {
+		if err := s.authenticate(ctx, conn); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	go s.watch(conn)
+
+	return conn, nil
+}
+
+// call sends a request over the stream and waits for its response, dialling a connection if required.
+func (s *stream) call(ctx context.Context, method string, params map[string]interface{}) (*ws.Response, error) {
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.Call(ctx, s.request(method, params))
+}
+
+func (s *stream) current() *ws.Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn
+}
+
+func (s *stream) dispatch(result ws.SubscriptionResult) {
+	s.mu.Lock()
+	subs := s.subs[result.Subscription]
+	if len(subs) == 0 && result.Channel == channelBook {
+		// book subscriptions without a depth are published with the Exchange's default depth
+		// (book.{instrument_name}.{depth}).
+		if i := strings.LastIndex(result.Subscription, "."); i >= 0 {
+			subs = s.subs[result.Subscription[:i]]
+		}
+	}
+	subs = append([]*subscription(nil), subs...)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.deliver(result.Data)
+	}
+}
+
+func (s *stream) unsubscribe(channel string, sub *subscription) {
+	if last := s.remove(channel, sub); last {
+		if conn := s.current(); conn != nil {
+			// best effort, the response is not waited on.
+			_ = conn.Send(s.request(ws.MethodUnsubscribe, map[string]interface{}{
+				"channels": []string{channel},
+			}))
+		}
+	}
+
+	sub.close()
+}
+
+// remove removes sub from channel, returning whether it was the last subscription to that channel.
+func (s *stream) remove(channel string, sub *subscription) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := s.subs[channel]
+	for i := range subs {
+		if subs[i] == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+
+	if len(subs) == 0 {
+		delete(s.subs, channel)
+		return true
+	}
+
+	s.subs[channel] = subs
+	return false
+}
+
+// channels returns the channels with at least one active subscription.
+func (s *stream) channels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channels := make([]string, 0, len(s.subs))
+	for channel := range s.subs {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	return channels
+}
+
+// closeAll closes and removes every subscription on the stream.
+func (s *stream) closeAll() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string][]*subscription)
+	s.mu.Unlock()
+
+	for _, channelSubs := range subs {
+		for _, sub := range channelSubs {
+			sub.close()
+		}
+	}
+}
+
+func (s *stream) close() error {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	cancel := s.cancelReconnect
+	s.cancelReconnect = nil
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+
+	s.closeAll()
+
+	return err
+}
+
+func (s *stream) request(method string, params map[string]interface{}) ws.Request {
+	return ws.Request{
+		ID:     s.client.idGenerator.Generate(),
+		Method: method,
+		Nonce:  s.client.nonce(),
+		Params: params,
+	}
+}
+
+func (s *subscription) deliver(data json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	// messages that cannot be decoded are dropped.
+	_ = s.handle(data, s.done)
+}
+
+func (s *subscription) close() {
+	s.once.Do(func() {
+		// closing done first unblocks any delivery waiting on a slow consumer.
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.onClose()
+	})
+}
@@ -0,0 +1,177 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/time"
+)
+
+const (
+	channelBook        = "book"
+	channelTicker      = "ticker"
+	channelTrade       = "trade"
+	channelCandlestick = "candlestick"
+
+	CandlestickInterval1Minute   CandlestickInterval = "1m"
+	CandlestickInterval5Minutes  CandlestickInterval = "5m"
+	CandlestickInterval15Minutes CandlestickInterval = "15m"
+	CandlestickInterval30Minutes CandlestickInterval = "30m"
+	CandlestickInterval1Hour     CandlestickInterval = "1h"
+	CandlestickInterval4Hours    CandlestickInterval = "4h"
+	CandlestickInterval6Hours    CandlestickInterval = "6h"
+	CandlestickInterval12Hours   CandlestickInterval = "12h"
+	CandlestickInterval1Day      CandlestickInterval = "1D"
+	CandlestickInterval7Days     CandlestickInterval = "7D"
+	CandlestickInterval14Days    CandlestickInterval = "14D"
+	CandlestickInterval1Month    CandlestickInterval = "1M"
+)
+
+type (
+	// CandlestickInterval is the period of a candlestick (e.g. 1m, 1h, 1D, etc).
+	CandlestickInterval string
+
+	// Candlestick represents a single candlestick (k-line) for an instrument.
+	Candlestick struct {
+		// Open is the open price.
+		Open decimal.Decimal `json:"o"`
+		// High is the highest price.
+		High decimal.Decimal `json:"h"`
+		// Low is the lowest price.
+		Low decimal.Decimal `json:"l"`
+		// Close is the close price.
+		Close decimal.Decimal `json:"c"`
+		// Volume is the traded volume.
+		Volume decimal.Decimal `json:"v"`
+		// Timestamp is the end time of the candlestick.
+		Timestamp time.Time `json:"t"`
+	}
+
+	// publicTrade is a trade as published on the trade.{instrument_name} channel.
+	publicTrade struct {
+		TradeID        json.Number     `json:"d"`
+		Side           OrderSide       `json:"s"`
+		Price          decimal.Decimal `json:"p"`
+		Quantity       decimal.Decimal `json:"q"`
+		CreateTime     time.Time       `json:"t"`
+		InstrumentName string          `json:"i"`
+	}
+)
+
+// SubscribeBook subscribes to order book updates for a particular instrument and depth.
+//
+// depth can be left as 0 to use the Exchange default, in which case updates are published on
+// book.{instrument_name}.{default depth}.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: book.{instrument_name}.{depth}
+func (c *Client) SubscribeBook(ctx context.Context, instrument string, depth int) (<-chan BookData, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+	if depth < 0 {
+		return nil, errors.InvalidParameterError{Parameter: "depth", Reason: "cannot be less than 0"}
+	}
+
+	channel := fmt.Sprintf("%s.%s", channelBook, instrument)
+	if depth > 0 {
+		channel = fmt.Sprintf("%s.%d", channel, depth)
+	}
+
+	ch := make(chan BookData, streamBufferSize)
+	if err := c.market.subscribeChan(ctx, channel, ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// SubscribeTickers subscribes to ticker updates for a particular instrument.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: ticker.{instrument_name}
+func (c *Client) SubscribeTickers(ctx context.Context, instrument string) (<-chan Ticker, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	ch := make(chan Ticker, streamBufferSize)
+	if err := c.market.subscribeChan(ctx, fmt.Sprintf("%s.%s", channelTicker, instrument), ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// SubscribeTrades subscribes to public trades for a particular instrument.
+//
+// Only the public fields of Trade are populated (side, instrument, trade ID, price, quantity & time).
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: trade.{instrument_name}
+func (c *Client) SubscribeTrades(ctx context.Context, instrument string) (<-chan Trade, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	ch := make(chan Trade, streamBufferSize)
+	if err := c.market.subscribeChan(ctx, fmt.Sprintf("%s.%s", channelTrade, instrument), ch, decodePublicTrades); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// SubscribeCandlesticks subscribes to candlestick updates for a particular instrument and interval.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: candlestick.{interval}.{instrument_name}
+func (c *Client) SubscribeCandlesticks(ctx context.Context, interval CandlestickInterval, instrument string) (<-chan Candlestick, error) {
+	if interval == "" {
+		return nil, errors.InvalidParameterError{Parameter: "interval", Reason: "cannot be empty"}
+	}
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	ch := make(chan Candlestick, streamBufferSize)
+	if err := c.market.subscribeChan(ctx, fmt.Sprintf("%s.%s.%s", channelCandlestick, interval, instrument), ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// decodePublicTrades decodes the data of a trade.{instrument_name} message into trades.
+func decodePublicTrades(data json.RawMessage) (interface{}, error) {
+	var publicTrades []publicTrade
+	if err := json.Unmarshal(data, &publicTrades); err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, 0, len(publicTrades))
+	for _, trade := range publicTrades {
+		trades = append(trades, trade.toTrade())
+	}
+
+	return trades, nil
+}
+
+func (t publicTrade) toTrade() Trade {
+	return Trade{
+		Side:           t.Side,
+		InstrumentName: t.InstrumentName,
+		TradeID:        t.TradeID.String(),
+		CreateTime:     t.CreateTime,
+		TradedPrice:    t.Price,
+		TradedQuantity: t.Quantity,
+	}
+}
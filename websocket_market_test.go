@@ -0,0 +1,311 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+func TestClient_SubscribeMarket_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+
+	tests := []struct {
+		name         string
+		subscribe    func(ctx context.Context, client *cdcexchange.Client) error
+		responseCode int
+		expectedErr  error
+	}{
+		{
+			name: "returns error when book instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeBook(ctx, "", 10)
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when book depth is less than 0",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeBook(ctx, "BTC_USDT", -1)
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "depth", Reason: "cannot be less than 0"},
+		},
+		{
+			name: "returns error when ticker instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeTickers(ctx, "")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when trade instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeTrades(ctx, "")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when candlestick interval is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeCandlesticks(ctx, "", "BTC_USDT")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "interval", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when candlestick instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeCandlesticks(ctx, cdcexchange.CandlestickInterval1Minute, "")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error given error response",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeTickers(ctx, "BTC_USDT")
+				return err
+			},
+			responseCode: 10004,
+			expectedErr: cdcerrors.ResponseError{
+				Code: 10004,
+				Err:  cdcerrors.ErrBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+				clock       = clockwork.NewFakeClock()
+			)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				req := readRequest(t, conn)
+				respond(t, conn, req.ID, ws.MethodSubscribe, tt.responseCode)
+
+				_, _, _ = conn.ReadMessage()
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithMarketWebsocketURL(url),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+			idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+			err = tt.subscribe(ctx, client)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+
+				assert.True(t, errors.Is(err, expectedResponseError.Err))
+			}
+		})
+	}
+}
+
+func TestClient_SubscribeMarket_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name            string
+		subscribe       func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error)
+		expectedChannel string
+		// publishedChannel is the subscription the server publishes on, defaults to expectedChannel.
+		publishedChannel string
+		data             string
+		expectedResult   interface{}
+	}{
+		{
+			name: "successfully subscribes to book",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeBook(ctx, instrument, 10)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "book.BTC_USDT.10",
+			data:            fmt.Sprintf(`[{"bids":[["1.1","2.2","3"]],"asks":[["4.4","5.5","6"]],"t":%d}]`, now.UnixMilli()),
+			expectedResult: cdcexchange.BookData{
+				Bids:      []cdcexchange.PriceLevel{priceLevel("1.1", "2.2", 3)},
+				Asks:      []cdcexchange.PriceLevel{priceLevel("4.4", "5.5", 6)},
+				Timestamp: cdctime.Time(now),
+			},
+		},
+		{
+			name: "successfully subscribes to book with default depth",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeBook(ctx, instrument, 0)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel:  "book.BTC_USDT",
+			publishedChannel: "book.BTC_USDT.50",
+			data:             fmt.Sprintf(`[{"bids":[],"asks":[],"t":%d}]`, now.UnixMilli()),
+			expectedResult: cdcexchange.BookData{
+				Bids:      []cdcexchange.PriceLevel{},
+				Asks:      []cdcexchange.PriceLevel{},
+				Timestamp: cdctime.Time(now),
+			},
+		},
+		{
+			name: "successfully subscribes to tickers",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeTickers(ctx, instrument)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "ticker.BTC_USDT",
+			data:            fmt.Sprintf(`[{"i":"BTC_USDT","b":"1","k":"2","a":"3","t":%d,"v":"4","h":"5","l":"6","c":"7"}]`, now.UnixMilli()),
+			expectedResult: cdcexchange.Ticker{
+				Instrument:       instrument,
+				BidPrice:         decimal.RequireFromString("1"),
+				AskPrice:         decimal.RequireFromString("2"),
+				LatestTradePrice: decimal.RequireFromString("3"),
+				Timestamp:        cdctime.Time(now),
+				Volume24H:        decimal.RequireFromString("4"),
+				PriceHigh24h:     decimal.RequireFromString("5"),
+				PriceLow24h:      decimal.RequireFromString("6"),
+				PriceChange24h:   decimal.RequireFromString("7"),
+			},
+		},
+		{
+			name: "successfully subscribes to trades",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeTrades(ctx, instrument)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "trade.BTC_USDT",
+			data:            fmt.Sprintf(`[{"dataTime":%d,"d":123456,"s":"BUY","p":1.5,"q":2.5,"t":%d,"i":"BTC_USDT"}]`, now.UnixMilli(), now.UnixMilli()),
+			expectedResult: cdcexchange.Trade{
+				Side:           cdcexchange.OrderSideBuy,
+				InstrumentName: instrument,
+				TradeID:        "123456",
+				CreateTime:     cdctime.Time(now),
+				TradedPrice:    decimal.RequireFromString("1.5"),
+				TradedQuantity: decimal.RequireFromString("2.5"),
+			},
+		},
+		{
+			name: "successfully subscribes to candlesticks",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeCandlesticks(ctx, cdcexchange.CandlestickInterval1Hour, instrument)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "candlestick.1h.BTC_USDT",
+			data:            fmt.Sprintf(`[{"t":%d,"o":1,"h":2,"l":3,"c":4,"v":5}]`, now.UnixMilli()),
+			expectedResult: cdcexchange.Candlestick{
+				Open:      decimal.RequireFromString("1"),
+				High:      decimal.RequireFromString("2"),
+				Low:       decimal.RequireFromString("3"),
+				Close:     decimal.RequireFromString("4"),
+				Volume:    decimal.RequireFromString("5"),
+				Timestamp: cdctime.Time(now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+				clock       = clockwork.NewFakeClockAt(now)
+				unsubscribe = make(chan ws.Request, 1)
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				req := readRequest(t, conn)
+				assert.Equal(t, ws.MethodSubscribe, req.Method)
+				assert.Equal(t, id, req.ID)
+				assert.Equal(t, now.UnixMilli(), req.Nonce)
+				assert.Equal(t, []interface{}{tt.expectedChannel}, req.Params["channels"])
+
+				respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+				publishedChannel := tt.expectedChannel
+				if tt.publishedChannel != "" {
+					publishedChannel = tt.publishedChannel
+				}
+				publish(t, conn, publishedChannel, tt.data)
+
+				unsubscribe <- readRequest(t, conn)
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithMarketWebsocketURL(url),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+			idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+			receive, err := tt.subscribe(ctx, client)
+			require.NoError(t, err)
+
+			res, ok := receive()
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedResult, res)
+
+			cancel()
+
+			_, ok = receive()
+			assert.False(t, ok)
+
+			select {
+			case req := <-unsubscribe:
+				assert.Equal(t, ws.MethodUnsubscribe, req.Method)
+				assert.Equal(t, []interface{}{tt.expectedChannel}, req.Params["channels"])
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for unsubscribe")
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package cdcexchange
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+const (
+	defaultReconnectMinBackoff = time.Second
+	defaultReconnectMaxBackoff = time.Minute
+
+	StreamEventDisconnected StreamEventType = "DISCONNECTED"
+	StreamEventResynced     StreamEventType = "RESYNCED"
+)
+
+// errHeartbeatTimeout is the reason given when a connection is dropped for not receiving any messages.
+var errHeartbeatTimeout = goerrors.New("no message received within heartbeat timeout")
+
+type (
+	// ReconnectPolicy configures how websocket connections are re-established after being lost.
+	ReconnectPolicy struct {
+		// MinBackoff is the delay before the first reconnect attempt, doubling on each failed attempt.
+		// (Default: 1s)
+		MinBackoff time.Duration
+		// MaxBackoff is the maximum delay between reconnect attempts.
+		// (Default: 1m)
+		MaxBackoff time.Duration
+		// MaxAttempts is the number of failed attempts after which subscriptions are closed.
+		// if MaxAttempts is 0, reconnects are attempted indefinitely.
+		MaxAttempts int
+		// HeartbeatTimeout is how long a connection can go without receiving a message before it is treated as lost.
+		// The Exchange sends a heartbeat every 30 seconds.
+		// if HeartbeatTimeout is 0, connections are only treated as lost once they are closed.
+		HeartbeatTimeout time.Duration
+	}
+
+	// StreamEventType is the type of websocket lifecycle event (DISCONNECTED or RESYNCED).
+	StreamEventType string
+
+	// StreamEvent is a websocket lifecycle event.
+	//
+	// Updates published while a connection is lost are missed, so any state built
+	// from subscriptions (e.g. order books) should be re-snapshotted on RESYNCED.
+	StreamEvent struct {
+		// Type is the type of event.
+		Type StreamEventType
+		// Stream is the websocket the event occurred on.
+		Stream StreamName
+		// Subscriptions is the list of channels which were replayed (RESYNCED only).
+		Subscriptions []string
+		// Err is the reason the connection was lost (DISCONNECTED only).
+		Err error
+		// Time is the time of the event.
+		Time time.Time
+	}
+
+	// eventBus fans out stream events to every registered consumer.
+	eventBus struct {
+		mu   sync.Mutex
+		subs map[chan StreamEvent]struct{}
+	}
+)
+
+// WithWebsocketReconnect will enable websocket connections to be re-established when they are lost.
+//
+// Once reconnected, the user websocket is re-authenticated and every active subscription is replayed,
+// after which a RESYNCED event is published (see SubscribeWebsocketEvents).
+func WithWebsocketReconnect(policy ReconnectPolicy) ClientOption {
+	return func(c *Client) error {
+		switch {
+		case policy.MinBackoff < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "cannot be less than 0"}
+		case policy.MaxBackoff < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than 0"}
+		case policy.MaxAttempts < 0:
+			return errors.InvalidParameterError{Parameter: "policy.MaxAttempts", Reason: "cannot be less than 0"}
+		case policy.HeartbeatTimeout < 0:
+			return errors.InvalidParameterError{Parameter: "policy.HeartbeatTimeout", Reason: "cannot be less than 0"}
+		}
+
+		if policy.MinBackoff == 0 {
+			policy.MinBackoff = defaultReconnectMinBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = defaultReconnectMaxBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			return errors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"}
+		}
+
+		c.reconnectPolicy = &policy
+		return nil
+	}
+}
+
+// SubscribeWebsocketEvents subscribes to websocket lifecycle events (disconnects & resyncs).
+//
+// Events are dropped if the returned channel is full.
+// The returned channel is closed once ctx is done.
+func (c *Client) SubscribeWebsocketEvents(ctx context.Context) <-chan StreamEvent {
+	ch := make(chan StreamEvent, streamBufferSize)
+
+	c.events.mu.Lock()
+	if c.events.subs == nil {
+		c.events.subs = make(map[chan StreamEvent]struct{})
+	}
+	c.events.subs[ch] = struct{}{}
+	c.events.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		c.events.mu.Lock()
+		delete(c.events.subs, ch)
+		c.events.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (b *eventBus) publish(event StreamEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// watch waits for conn to be closed, reconnecting if it was lost rather than closed by the Client.
+func (s *stream) watch(conn *ws.Conn) {
+	policy := s.client.reconnectPolicy
+
+	s.monitor(conn, policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.mu.Lock()
+	lost := s.conn == conn
+	if lost {
+		s.conn = nil
+	}
+	reconnect := lost && policy != nil && len(s.subs) > 0
+	if reconnect {
+		s.reconnects++
+		s.cancelReconnect = cancel
+	}
+	generation := s.reconnects
+	s.mu.Unlock()
+
+	if !lost {
+		return
+	}
+
+	s.client.events.publish(StreamEvent{
+		Type:   StreamEventDisconnected,
+		Stream: s.name,
+		Err:    conn.Err(),
+		Time:   s.client.clock.Now(),
+	})
+
+	if !reconnect {
+		s.closeAll()
+		return
+	}
+
+	s.reconnect(ctx, generation, *policy)
+}
+
+// monitor returns once conn is closed, closing it if no messages are received within the heartbeat timeout.
+func (s *stream) monitor(conn *ws.Conn, policy *ReconnectPolicy) {
+	if policy == nil || policy.HeartbeatTimeout == 0 {
+		<-conn.Done()
+		return
+	}
+
+	// a single timer is reset on each message, rather than a new timer being created for each.
+	timer := s.client.clock.NewTimer(policy.HeartbeatTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-conn.Done():
+			return
+		case <-conn.Activity():
+			if !timer.Stop() {
+				// drain the expiry of the timer if it fired but has not been received.
+				select {
+				case <-timer.Chan():
+				default:
+				}
+			}
+			timer.Reset(policy.HeartbeatTimeout)
+		case <-timer.Chan():
+			_ = conn.CloseWithError(errHeartbeatTimeout)
+		}
+	}
+}
+
+// reconnect dials a new connection with backoff and replays every active subscription on it.
+//
+// generation identifies this reconnect, cancelReconnect is only cleared if it has not since been replaced by a
+// newer reconnect (i.e. by the watcher of a connection which failed to resubscribe).
+func (s *stream) reconnect(ctx context.Context, generation uint64, policy ReconnectPolicy) {
+	defer func() {
+		s.mu.Lock()
+		if s.reconnects == generation {
+			s.cancelReconnect = nil
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		s.mu.Lock()
+		attempt := s.attempts
+		s.attempts++
+		s.mu.Unlock()
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			s.mu.Lock()
+			s.attempts = 0
+			s.mu.Unlock()
+
+			s.closeAll()
+			return
+		}
+
+		select {
+		case <-s.client.clock.After(policy.backoff(attempt)):
+		case <-ctx.Done():
+			s.closeAll()
+			return
+		}
+
+		conn, err := s.connect(ctx)
+		if err != nil {
+			continue
+		}
+
+		channels := s.channels()
+		err = s.resubscribe(ctx, conn, channels)
+		if ctx.Err() != nil {
+			// the stream was closed while reconnecting.
+			s.drop(conn)
+			s.closeAll()
+			return
+		}
+		if err != nil {
+			// a new watcher takes over from here, continuing from the current attempt.
+			_ = conn.CloseWithError(err)
+			return
+		}
+
+		s.mu.Lock()
+		s.attempts = 0
+		s.mu.Unlock()
+
+		s.client.events.publish(StreamEvent{
+			Type:          StreamEventResynced,
+			Stream:        s.name,
+			Subscriptions: channels,
+			Time:          s.client.clock.Now(),
+		})
+
+		return
+	}
+}
+
+func (s *stream) resubscribe(ctx context.Context, conn *ws.Conn, channels []string) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	if _, err := conn.Call(ctx, s.request(ws.MethodSubscribe, map[string]interface{}{
+		"channels": channels,
+	})); err != nil {
+		return fmt.Errorf("failed to resubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// drop closes conn without it being treated as lost.
+func (s *stream) drop(conn *ws.Conn) {
+	s.mu.Lock()
+	if s.conn == conn {
+		s.conn = nil
+	}
+	s.mu.Unlock()
+
+	_ = conn.Close()
+}
+
+// backoff returns the jittered delay before the given (0-based) reconnect attempt.
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	d := p.MaxBackoff
+	if attempt < 32 {
+		if exp := p.MinBackoff << uint(attempt); exp > 0 && exp < p.MaxBackoff {
+			d = exp
+		}
+	}
+
+	// jitter over the upper half of the delay so that many clients do not reconnect in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
@@ -0,0 +1,374 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+func TestWithWebsocketReconnect_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      cdcexchange.ReconnectPolicy
+		expectedErr error
+	}{
+		{
+			name:        "returns error when min backoff is less than 0",
+			policy:      cdcexchange.ReconnectPolicy{MinBackoff: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MinBackoff", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when max backoff is less than 0",
+			policy:      cdcexchange.ReconnectPolicy{MaxBackoff: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when max attempts is less than 0",
+			policy:      cdcexchange.ReconnectPolicy{MaxAttempts: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxAttempts", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when heartbeat timeout is less than 0",
+			policy:      cdcexchange.ReconnectPolicy{HeartbeatTimeout: -1},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.HeartbeatTimeout", Reason: "cannot be less than 0"},
+		},
+		{
+			name:        "returns error when max backoff is less than min backoff",
+			policy:      cdcexchange.ReconnectPolicy{MinBackoff: time.Minute, MaxBackoff: time.Second},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "policy.MaxBackoff", Reason: "cannot be less than policy.MinBackoff"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithWebsocketReconnect(tt.policy))
+			require.Error(t, err)
+
+			assert.Empty(t, client)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_Websocket_Reconnect(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+		signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+		clock              = clockwork.NewFakeClockAt(now)
+		connections        int32
+		resubscribe        = make(chan ws.Request, 1)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		acceptAuth(t, conn, apiKey, signature, 0)
+
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			// drop the first connection without a close handshake.
+			return
+		}
+
+		resubscribe <- req
+		publish(t, conn, "user.order.BTC_USDT", `[{"order_id":"1"}]`)
+
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithSignatureGenerator(signatureGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithUserWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnect(cdcexchange.ReconnectPolicy{
+			MinBackoff: time.Second,
+			MaxBackoff: time.Second,
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+	for _, timestamp := range []time.Time{now, now.Add(time.Second)} {
+		// the connection is re-authenticated once the backoff has elapsed.
+		signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+			APIKey:    apiKey,
+			SecretKey: secretKey,
+			ID:        id,
+			Method:    cdcexchange.MethodAuth,
+			Timestamp: timestamp.UnixMilli(),
+		}).Return(signature, nil)
+	}
+
+	events := client.SubscribeWebsocketEvents(ctx)
+
+	orders, err := client.SubscribeUserOrders(ctx, instrument)
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, cdcexchange.StreamEventDisconnected, event.Type)
+		assert.Equal(t, cdcexchange.StreamUser, event.Stream)
+		assert.Error(t, event.Err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for disconnected event")
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	select {
+	case req := <-resubscribe:
+		assert.Equal(t, ws.MethodSubscribe, req.Method)
+		assert.Equal(t, []interface{}{"user.order.BTC_USDT"}, req.Params["channels"])
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for resubscribe")
+	}
+
+	select {
+	case event := <-events:
+		assert.Equal(t, cdcexchange.StreamEventResynced, event.Type)
+		assert.Equal(t, cdcexchange.StreamUser, event.Stream)
+		assert.Equal(t, []string{"user.order.BTC_USDT"}, event.Subscriptions)
+		assert.Equal(t, now.Add(time.Second), event.Time)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for resynced event")
+	}
+
+	select {
+	case order, ok := <-orders:
+		require.True(t, ok)
+		assert.Equal(t, "1", order.OrderID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order")
+	}
+}
+
+func TestClient_Websocket_Reconnect_MaxAttempts(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+		connections int32
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		if atomic.AddInt32(&connections, 1) > 1 {
+			// reject every reconnect by dropping the connection before it is subscribed.
+			return
+		}
+
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+	})
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnect(cdcexchange.ReconnectPolicy{
+			MinBackoff:  time.Second,
+			MaxBackoff:  time.Second,
+			MaxAttempts: 1,
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	tickers, err := client.SubscribeTickers(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	select {
+	case _, ok := <-tickers:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to be closed")
+	}
+}
+
+func TestClient_Websocket_Reconnect_CloseAfterFailedResubscribe(t *testing.T) {
+	const id = int64(1234)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+		connections int32
+		resubscribe = make(chan struct{}, 1)
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		n := atomic.AddInt32(&connections, 1)
+
+		req := readRequest(t, conn)
+		if n == 1 {
+			// drop the first connection once subscribed.
+			respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+			return
+		}
+
+		// fail the resubscribe, handing over to the watcher of this connection.
+		respond(t, conn, req.ID, ws.MethodSubscribe, 10001)
+		resubscribe <- struct{}{}
+
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnect(cdcexchange.ReconnectPolicy{
+			MinBackoff: time.Second,
+			MaxBackoff: time.Second,
+		}),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	tickers, err := client.SubscribeTickers(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	select {
+	case <-resubscribe:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for resubscribe")
+	}
+
+	// wait for the reconnect taken over after the failed resubscribe.
+	clock.BlockUntil(1)
+
+	require.NoError(t, client.Close())
+
+	select {
+	case _, ok := <-tickers:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to be closed")
+	}
+
+	// the reconnect must have been stopped by Close.
+	clock.Advance(time.Second)
+	assert.Never(t, func() bool {
+		return atomic.LoadInt32(&connections) > 2
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestClient_Websocket_Reconnect_HeartbeatTimeout(t *testing.T) {
+	const (
+		heartbeatTimeout = 10 * time.Second
+		messages         = 100
+	)
+
+	var (
+		clock       = clockwork.NewFakeClock()
+		connections int32
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			for i := 0; i < messages; i++ {
+				publish(t, conn, "ticker.BTC_USDT", `[{"i":"BTC_USDT"}]`)
+			}
+		}
+
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client, err := cdcexchange.New("some api key", "some secret key",
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+		cdcexchange.WithWebsocketReconnect(cdcexchange.ReconnectPolicy{HeartbeatTimeout: heartbeatTimeout}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	events := client.SubscribeWebsocketEvents(ctx)
+
+	tickers, err := client.SubscribeTickers(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	for i := 0; i < messages; i++ {
+		select {
+		case <-tickers:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for ticker")
+		}
+	}
+
+	// the heartbeat timer, which is reset by each message.
+	clock.BlockUntil(1)
+
+	timeout := time.After(time.Second)
+	for {
+		// the timer may be reset by the last message after the clock has been advanced.
+		clock.Advance(heartbeatTimeout)
+
+		select {
+		case event := <-events:
+			assert.Equal(t, cdcexchange.StreamEventDisconnected, event.Type)
+			assert.Equal(t, cdcexchange.StreamMarket, event.Stream)
+			require.Error(t, event.Err)
+			assert.Contains(t, event.Err.Error(), "heartbeat timeout")
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for disconnected event")
+		}
+	}
+}
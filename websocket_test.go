@@ -0,0 +1,171 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+// newWebsocketServer starts a websocket server which calls handler for every connection made to it.
+// The returned URL can be used as a websocket URL on the Client.
+func newWebsocketServer(t *testing.T, handler func(conn *websocket.Conn)) string {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		handler(conn)
+	}))
+	t.Cleanup(s.Close)
+
+	return strings.Replace(s.URL, "http", "ws", 1)
+}
+
+// readRequest reads the next request sent by the Client, skipping heartbeat responses.
+func readRequest(t *testing.T, conn *websocket.Conn) ws.Request {
+	t.Helper()
+
+	for {
+		var req ws.Request
+		require.NoError(t, conn.ReadJSON(&req))
+
+		if req.Method != ws.MethodRespondHeartbeat {
+			return req
+		}
+	}
+}
+
+func respond(t *testing.T, conn *websocket.Conn, id int64, method string, code int) {
+	t.Helper()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"id":%d,"method":"%s","code":%d}`, id, method, code))))
+}
+
+func publish(t *testing.T, conn *websocket.Conn, subscription string, data string) {
+	t.Helper()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{
+		"id":-1,
+		"method":"subscribe",
+		"code":0,
+		"result":{
+			"subscription":"%s",
+			"channel":"%s",
+			"data":%s
+		}
+	}`, subscription, strings.Split(subscription, ".")[0], data))))
+}
+
+func TestClient_Websocket_Heartbeat(t *testing.T) {
+	const (
+		apiKey      = "some api key"
+		secretKey   = "some secret key"
+		id          = int64(1234)
+		heartbeatID = int64(5678)
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+		heartbeat   = make(chan ws.Request, 1)
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"id":%d,"method":"public/heartbeat","code":0}`, heartbeatID))))
+
+		var res ws.Request
+		require.NoError(t, conn.ReadJSON(&res))
+		heartbeat <- res
+
+		// wait for the client to close the connection.
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	_, err = client.SubscribeTickers(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	select {
+	case res := <-heartbeat:
+		assert.Equal(t, heartbeatID, res.ID)
+		assert.Equal(t, ws.MethodRespondHeartbeat, res.Method)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for heartbeat response")
+	}
+}
+
+func TestClient_Websocket_Close(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+	)
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	t.Cleanup(ctrl.Finish)
+
+	var (
+		idGenerator = id_mocks.NewMockIDGenerator(ctrl)
+		clock       = clockwork.NewFakeClock()
+	)
+
+	url := newWebsocketServer(t, func(conn *websocket.Conn) {
+		req := readRequest(t, conn)
+		respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client, err := cdcexchange.New(apiKey, secretKey,
+		cdcexchange.WithIDGenerator(idGenerator),
+		cdcexchange.WithClock(clock),
+		cdcexchange.WithMarketWebsocketURL(url),
+	)
+	require.NoError(t, err)
+
+	idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+
+	trades, err := client.SubscribeTrades(ctx, "BTC_USDT")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+
+	select {
+	case _, ok := <-trades:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to be closed")
+	}
+}
@@ -0,0 +1,175 @@
+package cdcexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+const (
+	methodAuth = "public/auth"
+
+	channelUserOrder   = "user.order"
+	channelUserTrade   = "user.trade"
+	channelUserBalance = "user.balance"
+)
+
+// SubscribeUserOrders subscribes to updates of the user's orders for a particular instrument.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: user.order.{instrument_name}
+func (c *Client) SubscribeUserOrders(ctx context.Context, instrument string) (<-chan Order, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	ch := make(chan Order, streamBufferSize)
+	if err := c.user.subscribeChan(ctx, fmt.Sprintf("%s.%s", channelUserOrder, instrument), ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// SubscribeUserTrades subscribes to the user's executed trades for a particular instrument.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: user.trade.{instrument_name}
+func (c *Client) SubscribeUserTrades(ctx context.Context, instrument string) (<-chan Trade, error) {
+	if instrument == "" {
+		return nil, errors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"}
+	}
+
+	ch := make(chan Trade, streamBufferSize)
+	if err := c.user.subscribeChan(ctx, fmt.Sprintf("%s.%s", channelUserTrade, instrument), ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// SubscribeUserBalance subscribes to updates of the user's balances.
+//
+// The returned channel is closed once ctx is done or the websocket connection is closed.
+//
+// Channel: user.balance
+func (c *Client) SubscribeUserBalance(ctx context.Context) (<-chan Account, error) {
+	ch := make(chan Account, streamBufferSize)
+	if err := c.user.subscribeChan(ctx, channelUserBalance, ch, nil); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// WebsocketCreateOrder creates a new BUY or SELL order on the Exchange over the user websocket.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.order subscription can be used to check when the order is successfully created.
+//
+// If the Client was created WithOrderValidation, req is validated against its instrument before being sent.
+//
+// Method: private/create-order
+func (c *Client) WebsocketCreateOrder(ctx context.Context, req CreateOrderRequest) (*CreateOrderResult, error) {
+	if err := c.validateOrder(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to validate order: %w", err)
+	}
+
+	res, err := c.user.call(ctx, methodCreateOrder, createOrderParams(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	var result CreateOrderResult
+	if err := json.Unmarshal(res.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// WebsocketCancelOrder cancels an existing order on the Exchange over the user websocket.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.order subscription can be used to check when the order is successfully cancelled.
+//
+// Method: private/cancel-order
+func (c *Client) WebsocketCancelOrder(ctx context.Context, instrumentName string, orderID string) error {
+	if instrumentName == "" {
+		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
+	}
+	if orderID == "" {
+		return errors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"}
+	}
+
+	if _, err := c.user.call(ctx, methodCancelOrder, map[string]interface{}{
+		"instrument_name": instrumentName,
+		"order_id":        orderID,
+	}); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	return nil
+}
+
+// WebsocketCancelAllOrders cancels all orders for a particular instrument/pair over the user websocket.
+//
+// This call is asynchronous, so the response is simply a confirmation of the request.
+//
+// The user.order subscription can be used to check when the order is successfully cancelled.
+//
+// Method: private/cancel-all-orders
+func (c *Client) WebsocketCancelAllOrders(ctx context.Context, instrumentName string) error {
+	if instrumentName == "" {
+		return errors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"}
+	}
+
+	if _, err := c.user.call(ctx, methodCancelAllOrders, map[string]interface{}{
+		"instrument_name": instrumentName,
+	}); err != nil {
+		return fmt.Errorf("failed to cancel all orders: %w", err)
+	}
+
+	return nil
+}
+
+// authenticate authenticates a user websocket connection using the Client's api key & secret key.
+//
+// Method: public/auth
+func (c *Client) authenticate(ctx context.Context, conn *ws.Conn) error {
+	var (
+		id        = c.idGenerator.Generate()
+		timestamp = c.nonce()
+	)
+
+	signature, err := c.signatureGenerator.GenerateSignature(auth.SignatureRequest{
+		APIKey:    c.apiKey,
+		SecretKey: c.secretKey,
+		ID:        id,
+		Method:    methodAuth,
+		Timestamp: timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+
+	if _, err := conn.Call(ctx, ws.Request{
+		ID:        id,
+		Method:    methodAuth,
+		Nonce:     timestamp,
+		Signature: signature,
+		APIKey:    c.apiKey,
+	}); err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return nil
+}
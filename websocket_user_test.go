@@ -0,0 +1,462 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/auth"
+	id_mocks "github.com/sngyai/go-cryptocom/internal/mocks/id"
+	signature_mocks "github.com/sngyai/go-cryptocom/internal/mocks/signature"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+	"github.com/sngyai/go-cryptocom/internal/ws"
+)
+
+// acceptAuth reads the public/auth request sent by the Client and responds with code.
+func acceptAuth(t *testing.T, conn *websocket.Conn, apiKey string, signature string, code int) {
+	t.Helper()
+
+	req := readRequest(t, conn)
+	assert.Equal(t, cdcexchange.MethodAuth, req.Method)
+	assert.Equal(t, apiKey, req.APIKey)
+	assert.Equal(t, signature, req.Signature)
+
+	respond(t, conn, req.ID, cdcexchange.MethodAuth, code)
+}
+
+func TestClient_SubscribeUser_Error(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+	)
+	testErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		subscribe    func(ctx context.Context, client *cdcexchange.Client) error
+		signatureErr error
+		authCode     int
+		expectedErr  error
+	}{
+		{
+			name: "returns error when order instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeUserOrders(ctx, "")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when trade instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeUserTrades(ctx, "")
+				return err
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrument", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when cancel order instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				return client.WebsocketCancelOrder(ctx, "", "some order id")
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when cancel order id is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				return client.WebsocketCancelOrder(ctx, "BTC_USDT", "")
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "orderID", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when cancel all orders instrument is empty",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				return client.WebsocketCancelAllOrders(ctx, "")
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "instrumentName", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error given error generating signature",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeUserBalance(ctx)
+				return err
+			},
+			signatureErr: testErr,
+			expectedErr:  testErr,
+		},
+		{
+			name: "returns error given error authenticating",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) error {
+				_, err := client.SubscribeUserBalance(ctx)
+				return err
+			},
+			authCode: 10002,
+			expectedErr: cdcerrors.ResponseError{
+				Code: 10002,
+				Err:  cdcerrors.ErrUnauthorized,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				now                = time.Now()
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				if tt.signatureErr == nil {
+					acceptAuth(t, conn, apiKey, signature, tt.authCode)
+				}
+
+				_, _, _ = conn.ReadMessage()
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+				cdcexchange.WithUserWebsocketURL(url),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+			idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodAuth,
+				Timestamp: now.UnixMilli(),
+			}).Return(signature, tt.signatureErr).AnyTimes()
+
+			err = tt.subscribe(ctx, client)
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.expectedErr))
+
+			var expectedResponseError cdcerrors.ResponseError
+			if errors.As(tt.expectedErr, &expectedResponseError) {
+				var responseError cdcerrors.ResponseError
+				require.True(t, errors.As(err, &responseError))
+
+				assert.Equal(t, expectedResponseError.Code, responseError.Code)
+				assert.Equal(t, expectedResponseError.Err, responseError.Err)
+			}
+		})
+	}
+}
+
+func TestClient_SubscribeUser_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrument = "BTC_USDT"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name            string
+		subscribe       func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error)
+		expectedChannel string
+		data            string
+		expectedResult  interface{}
+	}{
+		{
+			name: "successfully subscribes to user orders",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeUserOrders(ctx, instrument)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "user.order.BTC_USDT",
+			data: fmt.Sprintf(`[{
+				"status":"ACTIVE",
+				"side":"BUY",
+				"price":1.5,
+				"quantity":2,
+				"order_id":"some order id",
+				"client_oid":"some client oid",
+				"create_time":%d,
+				"update_time":%d,
+				"type":"LIMIT",
+				"instrument_name":"BTC_USDT",
+				"cumulative_quantity":1,
+				"cumulative_value":1.5,
+				"avg_price":1.5,
+				"fee_currency":"BTC",
+				"time_in_force":"GOOD_TILL_CANCEL"
+			}]`, now.UnixMilli(), now.UnixMilli()),
+			expectedResult: cdcexchange.Order{
+				Status:             cdcexchange.OrderStatusActive,
+				Side:               cdcexchange.OrderSideBuy,
+				Price:              decimal.RequireFromString("1.5"),
+				Quantity:           decimal.RequireFromString("2"),
+				OrderID:            "some order id",
+				ClientOID:          "some client oid",
+				CreateTime:         cdctime.Time(now),
+				UpdateTime:         cdctime.Time(now),
+				OrderType:          cdcexchange.OrderTypeLimit,
+				InstrumentName:     instrument,
+				CumulativeQuantity: decimal.RequireFromString("1"),
+				CumulativeValue:    decimal.RequireFromString("1.5"),
+				AvgPrice:           decimal.RequireFromString("1.5"),
+				FeeCurrency:        "BTC",
+				TimeInForce:        cdcexchange.TimeInForceGoodTilCancelled,
+			},
+		},
+		{
+			name: "successfully subscribes to user trades",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeUserTrades(ctx, instrument)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "user.trade.BTC_USDT",
+			data: fmt.Sprintf(`[{
+				"side":"SELL",
+				"instrument_name":"BTC_USDT",
+				"fee":0.01,
+				"trade_id":"some trade id",
+				"create_time":%d,
+				"traded_price":1.5,
+				"traded_quantity":2,
+				"fee_currency":"USDT",
+				"order_id":"some order id",
+				"liquidity_indicator":"MAKER"
+			}]`, now.UnixMilli()),
+			expectedResult: cdcexchange.Trade{
+				Side:               cdcexchange.OrderSideSell,
+				InstrumentName:     instrument,
+				Fee:                decimal.RequireFromString("0.01"),
+				TradeID:            "some trade id",
+				CreateTime:         cdctime.Time(now),
+				TradedPrice:        decimal.RequireFromString("1.5"),
+				TradedQuantity:     decimal.RequireFromString("2"),
+				FeeCurrency:        "USDT",
+				OrderID:            "some order id",
+				LiquidityIndicator: cdcexchange.LiquidityIndicatorMaker,
+			},
+		},
+		{
+			name: "successfully subscribes to user balance",
+			subscribe: func(ctx context.Context, client *cdcexchange.Client) (func() (interface{}, bool), error) {
+				ch, err := client.SubscribeUserBalance(ctx)
+				return func() (interface{}, bool) { v, ok := <-ch; return v, ok }, err
+			},
+			expectedChannel: "user.balance",
+			data:            `[{"currency":"CRO","balance":10,"available":6,"order":3,"stake":1}]`,
+			expectedResult: cdcexchange.Account{
+				Balance:   decimal.RequireFromString("10"),
+				Available: decimal.RequireFromString("6"),
+				Order:     decimal.RequireFromString("3"),
+				Stake:     decimal.RequireFromString("1"),
+				Currency:  "CRO",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				acceptAuth(t, conn, apiKey, signature, 0)
+
+				req := readRequest(t, conn)
+				assert.Equal(t, ws.MethodSubscribe, req.Method)
+				assert.Equal(t, []interface{}{tt.expectedChannel}, req.Params["channels"])
+
+				respond(t, conn, req.ID, ws.MethodSubscribe, 0)
+				publish(t, conn, tt.expectedChannel, tt.data)
+
+				_, _, _ = conn.ReadMessage()
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+				cdcexchange.WithUserWebsocketURL(url),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+			idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodAuth,
+				Timestamp: now.UnixMilli(),
+			}).Return(signature, nil)
+
+			receive, err := tt.subscribe(ctx, client)
+			require.NoError(t, err)
+
+			res, ok := receive()
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedResult, res)
+		})
+	}
+}
+
+func TestClient_WebsocketOrders_Success(t *testing.T) {
+	const (
+		apiKey    = "some api key"
+		secretKey = "some secret key"
+		id        = int64(1234)
+		signature = "some signature"
+
+		instrument = "BTC_USDT"
+		orderID    = "some order id"
+		clientOID  = "some client oid"
+	)
+	now := time.Now().Round(time.Second)
+
+	tests := []struct {
+		name           string
+		call           func(ctx context.Context, client *cdcexchange.Client) (interface{}, error)
+		expectedMethod string
+		expectedParams map[string]interface{}
+		result         string
+		expectedResult interface{}
+	}{
+		{
+			name: "successfully creates order",
+			call: func(ctx context.Context, client *cdcexchange.Client) (interface{}, error) {
+				return client.WebsocketCreateOrder(ctx, cdcexchange.CreateOrderRequest{
+					InstrumentName: instrument,
+					Side:           cdcexchange.OrderSideBuy,
+					Type:           cdcexchange.OrderTypeLimit,
+					Price:          decimal.RequireFromString("1.5"),
+					Quantity:       decimal.RequireFromString("2"),
+					ClientOID:      clientOID,
+				})
+			},
+			expectedMethod: cdcexchange.MethodCreateOrder,
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrument,
+				"side":            string(cdcexchange.OrderSideBuy),
+				"type":            string(cdcexchange.OrderTypeLimit),
+				"price":           "1.5",
+				"quantity":        "2",
+				"client_oid":      clientOID,
+			},
+			result: fmt.Sprintf(`{"order_id":"%s","client_oid":"%s"}`, orderID, clientOID),
+			expectedResult: &cdcexchange.CreateOrderResult{
+				OrderID:   orderID,
+				ClientOID: clientOID,
+			},
+		},
+		{
+			name: "successfully cancels order",
+			call: func(ctx context.Context, client *cdcexchange.Client) (interface{}, error) {
+				return nil, client.WebsocketCancelOrder(ctx, instrument, orderID)
+			},
+			expectedMethod: cdcexchange.MethodCancelOrder,
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrument,
+				"order_id":        orderID,
+			},
+		},
+		{
+			name: "successfully cancels all orders",
+			call: func(ctx context.Context, client *cdcexchange.Client) (interface{}, error) {
+				return nil, client.WebsocketCancelAllOrders(ctx, instrument)
+			},
+			expectedMethod: cdcexchange.MethodCancelAllOrders,
+			expectedParams: map[string]interface{}{
+				"instrument_name": instrument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			t.Cleanup(ctrl.Finish)
+
+			var (
+				idGenerator        = id_mocks.NewMockIDGenerator(ctrl)
+				signatureGenerator = signature_mocks.NewMockSignatureGenerator(ctrl)
+				clock              = clockwork.NewFakeClockAt(now)
+			)
+
+			url := newWebsocketServer(t, func(conn *websocket.Conn) {
+				acceptAuth(t, conn, apiKey, signature, 0)
+
+				req := readRequest(t, conn)
+				assert.Equal(t, tt.expectedMethod, req.Method)
+				assert.Equal(t, id, req.ID)
+				assert.Equal(t, now.UnixMilli(), req.Nonce)
+				assert.Equal(t, tt.expectedParams, req.Params)
+
+				result := tt.result
+				if result == "" {
+					result = "null"
+				}
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(
+					`{"id":%d,"method":"%s","code":0,"result":%s}`, req.ID, req.Method, result,
+				))))
+
+				_, _, _ = conn.ReadMessage()
+			})
+
+			client, err := cdcexchange.New(apiKey, secretKey,
+				cdcexchange.WithIDGenerator(idGenerator),
+				cdcexchange.WithClock(clock),
+				cdcexchange.WithSignatureGenerator(signatureGenerator),
+				cdcexchange.WithUserWebsocketURL(url),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+			idGenerator.EXPECT().Generate().Return(id).AnyTimes()
+			signatureGenerator.EXPECT().GenerateSignature(auth.SignatureRequest{
+				APIKey:    apiKey,
+				SecretKey: secretKey,
+				ID:        id,
+				Method:    cdcexchange.MethodAuth,
+				Timestamp: now.UnixMilli(),
+			}).Return(signature, nil)
+
+			res, err := tt.call(ctx, client)
+			require.NoError(t, err)
+
+			if tt.expectedResult != nil {
+				assert.Equal(t, tt.expectedResult, res)
+			}
+		})
+	}
+}
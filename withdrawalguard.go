@@ -0,0 +1,246 @@
+package cdcexchange
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+// withdrawalLimitWindow is the period over which WithdrawalLimit.Rolling24h is enforced.
+const withdrawalLimitWindow = 24 * time.Hour
+
+type (
+	// WithdrawalGuard configures client-side checks which every withdrawal must pass before it is signed & sent.
+	WithdrawalGuard struct {
+		// Allowlist is the list of destinations withdrawals can be sent to.
+		// if Allowlist is empty, withdrawals can be sent to any destination.
+		Allowlist []WithdrawalDestination
+		// Limits is the amount limits of each currency (e.g. BTC).
+		// if a currency has no limits, any amount of it can be withdrawn.
+		Limits map[string]WithdrawalLimit
+		// Confirm is an optional callback which is called once a withdrawal has passed every other check.
+		// The withdrawal is only sent if Confirm returns true.
+		Confirm func(ctx context.Context, req CreateWithdrawalRequest) (bool, error)
+	}
+
+	// WithdrawalDestination is a destination which withdrawals are allowed to be sent to.
+	//
+	// Withdrawals must match every field (including empty NetworkId & AddressTag).
+	WithdrawalDestination struct {
+		// Currency is the currency symbol which can be withdrawn (e.g. BTC or ETH).
+		Currency string
+		// NetworkId is the network the withdrawal is sent on (empty for the default network of the currency).
+		NetworkId NetworkID
+		// Address is the address the withdrawal is sent to.
+		Address string
+		// AddressTag is the secondary address identifier (e.g. memo or tag), if required by the currency.
+		AddressTag string
+	}
+
+	// WithdrawalLimit is the maximum amount of a currency which can be withdrawn.
+	//
+	// Zero limits are not enforced.
+	WithdrawalLimit struct {
+		// Single is the maximum amount of a single withdrawal.
+		Single decimal.Decimal
+		// Rolling24h is the maximum total amount of withdrawals sent by the Client in any 24 hour period.
+		Rolling24h decimal.Decimal
+	}
+
+	// withdrawalGuard enforces a WithdrawalGuard, tracking the withdrawals sent in the last 24 hours.
+	withdrawalGuard struct {
+		config WithdrawalGuard
+
+		mu     sync.Mutex
+		ledger map[string][]withdrawalRecord
+	}
+
+	// withdrawalRecord is a withdrawal counted towards a rolling limit.
+	withdrawalRecord struct {
+		time   time.Time
+		amount decimal.Decimal
+	}
+)
+
+// WithWithdrawalGuard will initialise the Client to check every withdrawal against guard before it is sent.
+//
+// Withdrawals which fail a check return errors.WithdrawalGuardError.
+//
+// Rolling limits are tracked in memory, so only withdrawals sent by this Client (since it was created) are counted.
+// guard is copied, so changes made to its Allowlist or Limits afterwards do not affect the Client.
+func WithWithdrawalGuard(guard WithdrawalGuard) ClientOption {
+	return func(c *Client) error {
+		for i, destination := range guard.Allowlist {
+			switch {
+			case destination.Currency == "":
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("guard.Allowlist[%d].Currency", i), Reason: "cannot be empty"}
+			case destination.Address == "":
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("guard.Allowlist[%d].Address", i), Reason: "cannot be empty"}
+			}
+		}
+		for currency, limit := range guard.Limits {
+			switch {
+			case limit.Single.IsNegative():
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("guard.Limits[%s].Single", currency), Reason: "cannot be less than 0"}
+			case limit.Rolling24h.IsNegative():
+				return errors.InvalidParameterError{Parameter: fmt.Sprintf("guard.Limits[%s].Rolling24h", currency), Reason: "cannot be less than 0"}
+			}
+		}
+
+		guard.Allowlist = append([]WithdrawalDestination(nil), guard.Allowlist...)
+
+		limits := make(map[string]WithdrawalLimit, len(guard.Limits))
+		for currency, limit := range guard.Limits {
+			limits[currency] = limit
+		}
+		guard.Limits = limits
+
+		c.withdrawalGuard = &withdrawalGuard{
+			config: guard,
+			ledger: make(map[string][]withdrawalRecord),
+		}
+		return nil
+	}
+}
+
+// guardWithdrawal checks req against the Client's withdrawal guard and counts it towards the rolling limits.
+//
+// The returned release func un-counts the withdrawal, and should be called if it is rejected by the Exchange.
+func (c *Client) guardWithdrawal(ctx context.Context, req CreateWithdrawalRequest) (release func(), err error) {
+	g := c.withdrawalGuard
+	if g == nil {
+		return func() {}, nil
+	}
+
+	if !g.allowed(req) {
+		return nil, errors.WithdrawalGuardError{
+			Reason: fmt.Sprintf("%s address %s is not allowed", req.Currency, req.Address),
+			Err:    errors.ErrWithdrawalNotAllowed,
+		}
+	}
+
+	release, err = g.reserve(c.clock.Now(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.config.Confirm != nil {
+		confirmed, err := g.config.Confirm(ctx, req)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("failed to confirm withdrawal: %w", err)
+		}
+		if !confirmed {
+			release()
+			return nil, errors.WithdrawalGuardError{
+				Reason: fmt.Sprintf("%s %s to %s was rejected", req.Amount, req.Currency, req.Address),
+				Err:    errors.ErrWithdrawalNotConfirmed,
+			}
+		}
+	}
+
+	return release, nil
+}
+
+// allowed returns whether the destination of req is in the allowlist.
+func (g *withdrawalGuard) allowed(req CreateWithdrawalRequest) bool {
+	if len(g.config.Allowlist) == 0 {
+		return true
+	}
+
+	for _, d := range g.config.Allowlist {
+		if strings.EqualFold(d.Currency, req.Currency) &&
+			strings.EqualFold(string(d.NetworkId), string(req.NetworkId)) &&
+			d.Address == req.Address &&
+			d.AddressTag == req.AddressTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reserve checks req against the limits of its currency and records it in the ledger.
+func (g *withdrawalGuard) reserve(now time.Time, req CreateWithdrawalRequest) (func(), error) {
+	currency := strings.ToUpper(req.Currency)
+
+	limit, ok := g.limit(currency)
+	if !ok {
+		return func() {}, nil
+	}
+
+	if !limit.Single.IsZero() && req.Amount.GreaterThan(limit.Single) {
+		return nil, errors.WithdrawalGuardError{
+			Reason: fmt.Sprintf("%s single withdrawal limit is %s", currency, limit.Single),
+			Err:    errors.ErrWithdrawalLimitExceeded,
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// drop withdrawals which have left the window.
+	records := g.ledger[currency][:0]
+	total := decimal.Zero
+	for _, r := range g.ledger[currency] {
+		if now.Sub(r.time) < withdrawalLimitWindow {
+			records = append(records, r)
+			total = total.Add(r.amount)
+		}
+	}
+	g.ledger[currency] = records
+
+	if !limit.Rolling24h.IsZero() && total.Add(req.Amount).GreaterThan(limit.Rolling24h) {
+		return nil, errors.WithdrawalGuardError{
+			Reason: fmt.Sprintf("%s 24h withdrawal limit is %s (%s already withdrawn)", currency, limit.Rolling24h, total),
+			Err:    errors.ErrWithdrawalLimitExceeded,
+		}
+	}
+
+	record := withdrawalRecord{time: now, amount: req.Amount}
+	g.ledger[currency] = append(g.ledger[currency], record)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+
+			for i, r := range g.ledger[currency] {
+				if r.time.Equal(record.time) && r.amount.Equal(record.amount) {
+					g.ledger[currency] = append(g.ledger[currency][:i], g.ledger[currency][i+1:]...)
+					return
+				}
+			}
+		})
+	}, nil
+}
+
+// limit returns the limits of currency, matching its symbol case-insensitively.
+func (g *withdrawalGuard) limit(currency string) (WithdrawalLimit, bool) {
+	for c, limit := range g.config.Limits {
+		if strings.EqualFold(c, currency) {
+			return limit, true
+		}
+	}
+
+	return WithdrawalLimit{}, false
+}
+
+// withdrawalRejected returns whether err shows that a withdrawal was rejected by the Exchange, rather than it being
+// unknown whether it was processed.
+func withdrawalRejected(err error) bool {
+	var responseErr errors.ResponseError
+	if !goerrors.As(err, &responseErr) {
+		return false
+	}
+
+	return responseErr.Code != 0 && !goerrors.Is(responseErr.Err, errors.ErrSystemError) && !goerrors.Is(responseErr.Err, errors.ErrUnexpectedError)
+}
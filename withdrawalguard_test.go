@@ -0,0 +1,258 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+)
+
+func TestWithWithdrawalGuard_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		guard       cdcexchange.WithdrawalGuard
+		expectedErr error
+	}{
+		{
+			name: "returns error when allowlist currency is empty",
+			guard: cdcexchange.WithdrawalGuard{
+				Allowlist: []cdcexchange.WithdrawalDestination{{Address: "some address"}},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "guard.Allowlist[0].Currency", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when allowlist address is empty",
+			guard: cdcexchange.WithdrawalGuard{
+				Allowlist: []cdcexchange.WithdrawalDestination{{Currency: "BTC"}},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "guard.Allowlist[0].Address", Reason: "cannot be empty"},
+		},
+		{
+			name: "returns error when single limit is negative",
+			guard: cdcexchange.WithdrawalGuard{
+				Limits: map[string]cdcexchange.WithdrawalLimit{"BTC": {Single: decimal.NewFromInt(-1)}},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "guard.Limits[BTC].Single", Reason: "cannot be less than 0"},
+		},
+		{
+			name: "returns error when rolling limit is negative",
+			guard: cdcexchange.WithdrawalGuard{
+				Limits: map[string]cdcexchange.WithdrawalLimit{"BTC": {Rolling24h: decimal.NewFromInt(-1)}},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "guard.Limits[BTC].Rolling24h", Reason: "cannot be less than 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key", cdcexchange.WithWithdrawalGuard(tt.guard))
+			require.Error(t, err)
+
+			assert.Empty(t, client)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_CreateWithdrawal_Guard(t *testing.T) {
+	const address = "some address"
+	now := time.Now().Round(time.Second)
+
+	// newClient returns a Client whose withdrawals are rejected with code while it is non-zero,
+	// and a func returning the number of withdrawals sent.
+	newClient := func(t *testing.T, guard cdcexchange.WithdrawalGuard, code *int32) (*cdcexchange.Client, clockwork.FakeClock, func() int32) {
+		var (
+			clock = clockwork.NewFakeClockAt(now)
+			sent  int32
+		)
+
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.URL.Path, cdcexchange.MethodCreateWithdrawal)
+			atomic.AddInt32(&sent, 1)
+
+			res := `{"id":0,"method":"","code":0,"result":{"id":1,"symbol":"BTC","amount":1,"fee":0.0004,"address":"some address","create_time":1607063412000}}`
+			if code != nil && atomic.LoadInt32(code) != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				res = fmt.Sprintf(`{"id":0,"method":"","code":%d}`, atomic.LoadInt32(code))
+			}
+			_, err := w.Write([]byte(res))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(s.Close)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+			cdcexchange.WithWithdrawalGuard(guard),
+		)
+		require.NoError(t, err)
+
+		return client, clock, func() int32 { return atomic.LoadInt32(&sent) }
+	}
+	withdraw := func(client *cdcexchange.Client, currency string, amount string, address string) error {
+		_, err := client.CreateWithdrawal(context.Background(), cdcexchange.CreateWithdrawalRequest{
+			Currency: currency,
+			Amount:   decimal.RequireFromString(amount),
+			Address:  address,
+		})
+		return err
+	}
+
+	t.Run("only sends withdrawals to allowed destinations", func(t *testing.T) {
+		client, _, sent := newClient(t, cdcexchange.WithdrawalGuard{
+			Allowlist: []cdcexchange.WithdrawalDestination{
+				{Currency: "BTC", Address: address},
+				{Currency: "XRP", Address: address, AddressTag: "some tag"},
+			},
+		}, nil)
+
+		require.NoError(t, withdraw(client, "btc", "1", address))
+
+		err := withdraw(client, "BTC", "1", "some other address")
+		var guardErr cdcerrors.WithdrawalGuardError
+		require.True(t, errors.As(err, &guardErr))
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalNotAllowed))
+
+		// the tag must also match.
+		err = withdraw(client, "XRP", "1", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalNotAllowed))
+
+		_, err = client.CreateWithdrawal(context.Background(), cdcexchange.CreateWithdrawalRequest{
+			Currency:   "XRP",
+			Amount:     decimal.RequireFromString("1"),
+			Address:    address,
+			AddressTag: "some tag",
+		})
+		require.NoError(t, err)
+
+		// as well as the network.
+		_, err = client.CreateWithdrawal(context.Background(), cdcexchange.CreateWithdrawalRequest{
+			Currency:  "BTC",
+			Amount:    decimal.RequireFromString("1"),
+			Address:   address,
+			NetworkId: "BSC",
+		})
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalNotAllowed))
+
+		assert.Equal(t, int32(2), sent())
+	})
+
+	t.Run("enforces single & rolling 24h limits", func(t *testing.T) {
+		client, clock, sent := newClient(t, cdcexchange.WithdrawalGuard{
+			Limits: map[string]cdcexchange.WithdrawalLimit{
+				"BTC": {Single: decimal.RequireFromString("0.5"), Rolling24h: decimal.RequireFromString("1")},
+			},
+		}, nil)
+
+		err := withdraw(client, "BTC", "0.6", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalLimitExceeded))
+
+		require.NoError(t, withdraw(client, "BTC", "0.5", address))
+		clock.Advance(12 * time.Hour)
+		require.NoError(t, withdraw(client, "BTC", "0.5", address))
+
+		err = withdraw(client, "BTC", "0.1", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalLimitExceeded))
+
+		// other currencies are not limited.
+		require.NoError(t, withdraw(client, "ETH", "100", address))
+
+		// the first withdrawal leaves the window.
+		clock.Advance(12 * time.Hour)
+		require.NoError(t, withdraw(client, "BTC", "0.5", address))
+
+		err = withdraw(client, "BTC", "0.1", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalLimitExceeded))
+
+		assert.Equal(t, int32(4), sent())
+	})
+
+	t.Run("is not changed by the guard it was created from", func(t *testing.T) {
+		guard := cdcexchange.WithdrawalGuard{
+			Allowlist: []cdcexchange.WithdrawalDestination{
+				{Currency: "BTC", Address: address},
+			},
+			Limits: map[string]cdcexchange.WithdrawalLimit{
+				"BTC": {Single: decimal.RequireFromString("1")},
+			},
+		}
+		client, _, sent := newClient(t, guard, nil)
+
+		guard.Allowlist[0].Address = "some other address"
+		guard.Limits["BTC"] = cdcexchange.WithdrawalLimit{Single: decimal.RequireFromString("10")}
+
+		require.NoError(t, withdraw(client, "BTC", "1", address))
+
+		err := withdraw(client, "BTC", "1", "some other address")
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalNotAllowed))
+
+		err = withdraw(client, "BTC", "2", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalLimitExceeded))
+
+		assert.Equal(t, int32(1), sent())
+	})
+
+	t.Run("does not count withdrawals rejected by the exchange", func(t *testing.T) {
+		code := int32(20002)
+		client, _, sent := newClient(t, cdcexchange.WithdrawalGuard{
+			Limits: map[string]cdcexchange.WithdrawalLimit{
+				"BTC": {Rolling24h: decimal.RequireFromString("1")},
+			},
+		}, &code)
+
+		err := withdraw(client, "BTC", "1", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrNegativeBalance))
+
+		atomic.StoreInt32(&code, 0)
+		require.NoError(t, withdraw(client, "BTC", "1", address))
+
+		assert.Equal(t, int32(2), sent())
+	})
+
+	t.Run("only sends confirmed withdrawals", func(t *testing.T) {
+		testErr := errors.New("some error")
+
+		var confirmed []cdcexchange.CreateWithdrawalRequest
+		client, _, sent := newClient(t, cdcexchange.WithdrawalGuard{
+			Limits: map[string]cdcexchange.WithdrawalLimit{
+				"BTC": {Rolling24h: decimal.RequireFromString("1")},
+			},
+			Confirm: func(ctx context.Context, req cdcexchange.CreateWithdrawalRequest) (bool, error) {
+				confirmed = append(confirmed, req)
+				switch req.Address {
+				case "approved address":
+					return true, nil
+				case "erroring address":
+					return false, testErr
+				default:
+					return false, nil
+				}
+			},
+		}, nil)
+
+		err := withdraw(client, "BTC", "1", address)
+		assert.True(t, errors.Is(err, cdcerrors.ErrWithdrawalNotConfirmed))
+
+		err = withdraw(client, "BTC", "1", "erroring address")
+		assert.True(t, errors.Is(err, testErr))
+
+		// rejected withdrawals do not count towards the limit.
+		require.NoError(t, withdraw(client, "BTC", "1", "approved address"))
+
+		require.Len(t, confirmed, 3)
+		assert.Equal(t, "approved address", confirmed[2].Address)
+		assert.Equal(t, int32(1), sent())
+	})
+}
@@ -0,0 +1,154 @@
+package cdcexchange
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sngyai/go-cryptocom/errors"
+)
+
+const (
+	// withdrawalSearchMargin is how long before its creation time a withdrawal is searched for,
+	// allowing for the Client's clock being ahead of the Exchange's.
+	withdrawalSearchMargin = time.Minute
+	// withdrawalSearchWindow is the maximum duration of a private/get-withdrawal-history query.
+	withdrawalSearchWindow = 24 * time.Hour
+	// withdrawalSearchPageSize is the page size used to search the withdrawal history.
+	withdrawalSearchPageSize = 200
+)
+
+type (
+	// WaitForWithdrawalRequest is the request params for WaitForWithdrawal.
+	//
+	// The withdrawal is identified by ID or ClientWid (at least one must be set).
+	WaitForWithdrawalRequest struct {
+		// ID is the withdrawal ID (CreateWithdrawalResult.Id).
+		ID string
+		// ClientWid is the client withdrawal ID the withdrawal was created with.
+		ClientWid string
+		// Currency is the currency symbol of the withdrawal (e.g. BTC), which narrows the history searched.
+		Currency string
+		// CreateTime is the withdrawal creation time (CreateWithdrawalResult.CreateTime), which narrows the history searched.
+		// if CreateTime is zero, the last 24 hours of history are searched.
+		CreateTime time.Time
+		// Policy configures how often the withdrawal history is polled.
+		Policy PollPolicy
+		// OnStatusChange is called with the withdrawal each time its status is seen to change
+		// (including the first time it is found), before WaitForWithdrawal returns.
+		OnStatusChange func(withdrawal Withdrawal)
+	}
+
+	// WithdrawalFailedError is returned from WaitForWithdrawal when a withdrawal reaches a terminal status other than COMPLETED
+	// (REJECTED, PAYMENT_FAILED or CANCELLED).
+	WithdrawalFailedError struct {
+		// Withdrawal is the final state of the withdrawal.
+		Withdrawal Withdrawal
+	}
+)
+
+// Error returns the error message.
+func (e WithdrawalFailedError) Error() string {
+	return fmt.Sprintf("withdrawal %s failed with status %s", e.Withdrawal.Id, e.Withdrawal.Status)
+}
+
+// WaitForWithdrawalRequest returns the request params to wait for the created withdrawal.
+func (r CreateWithdrawalResult) WaitForWithdrawalRequest() WaitForWithdrawalRequest {
+	return WaitForWithdrawalRequest{
+		ID:         strconv.FormatInt(r.Id, 10),
+		ClientWid:  r.ClientWid,
+		Currency:   r.Symbol,
+		CreateTime: r.CreateTime.Time(),
+	}
+}
+
+// WaitForWithdrawal polls the withdrawal history with backoff until the withdrawal reaches a terminal status.
+//
+// The final withdrawal is returned once it is COMPLETED (at which point its Txid is set).
+// If it is REJECTED, fails payment or is CANCELLED, WithdrawalFailedError is returned.
+//
+// Polling continues while the withdrawal history cannot be fetched due to a transient failure (see WithRetry),
+// until ctx is done. Any other error is returned immediately.
+//
+// Method: private/get-withdrawal-history
+func (c *Client) WaitForWithdrawal(ctx context.Context, req WaitForWithdrawalRequest) (*Withdrawal, error) {
+	if req.ID == "" && req.ClientWid == "" {
+		return nil, errors.InvalidParameterError{Parameter: "req.ID", Reason: "cannot be empty if req.ClientWid is empty"}
+	}
+
+	policy, err := req.Policy.withDefaults("req.Policy")
+	if err != nil {
+		return nil, err
+	}
+
+	historyReq := GetWithdrawalHistoryRequest{
+		Currency: req.Currency,
+		PageSize: withdrawalSearchPageSize,
+	}
+	if !req.CreateTime.IsZero() {
+		historyReq.Start = req.CreateTime.Add(-withdrawalSearchMargin)
+		historyReq.End = historyReq.Start.Add(withdrawalSearchWindow)
+	}
+
+	var (
+		withdrawal *Withdrawal
+		status     WithdrawalStatus
+	)
+	err = c.poll(ctx, policy, func() (bool, error) {
+		w, err := c.findWithdrawal(ctx, historyReq, req)
+		switch {
+		case err != nil && retryable(err, true):
+			// transient failures (e.g. rate limits or system errors) are polled through.
+			return false, nil
+		case err != nil:
+			return false, err
+		case w == nil:
+			// the withdrawal may not appear in the history straight away.
+			return false, nil
+		}
+
+		if withdrawal == nil || w.Status != status {
+			status = w.Status
+			if req.OnStatusChange != nil {
+				req.OnStatusChange(*w)
+			}
+		}
+		withdrawal = w
+
+		return w.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for withdrawal: %w", err)
+	}
+
+	if withdrawal.Status != WithdrawalStatusCompleted {
+		return nil, WithdrawalFailedError{Withdrawal: *withdrawal}
+	}
+
+	return withdrawal, nil
+}
+
+// findWithdrawal searches each page of the withdrawal history for the withdrawal identified by req.
+//
+// nil is returned if the withdrawal is not found.
+func (c *Client) findWithdrawal(ctx context.Context, historyReq GetWithdrawalHistoryRequest, req WaitForWithdrawalRequest) (*Withdrawal, error) {
+	for page := 0; ; page++ {
+		historyReq.Page = page
+
+		withdrawals, err := c.GetWithdrawalHistory(ctx, historyReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, w := range withdrawals {
+			if (req.ID != "" && w.Id == req.ID) || (req.ClientWid != "" && w.ClientWid == req.ClientWid) {
+				return &withdrawals[i], nil
+			}
+		}
+
+		if len(withdrawals) < historyReq.PageSize {
+			return nil, nil
+		}
+	}
+}
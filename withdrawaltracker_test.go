@@ -0,0 +1,255 @@
+package cdcexchange_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cdcexchange "github.com/sngyai/go-cryptocom"
+	cdcerrors "github.com/sngyai/go-cryptocom/errors"
+	"github.com/sngyai/go-cryptocom/internal/api"
+	cdctime "github.com/sngyai/go-cryptocom/internal/time"
+)
+
+// withdrawalHistoryServer serves each withdrawal list in turn from private/get-withdrawal-history,
+// repeating the last once they are exhausted.
+func withdrawalHistoryServer(t *testing.T, pages ...string) (*httptest.Server, func() []api.Request) {
+	return apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+		assert.Equal(t, cdcexchange.MethodGetWithdrawalHistory, req.Method)
+
+		return okResponse(fmt.Sprintf(`{"withdrawal_list":[%s]}`, nthOrLast(pages, n)))
+	})
+}
+
+func TestClient_WaitForWithdrawal_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         cdcexchange.WaitForWithdrawalRequest
+		expectedErr error
+	}{
+		{
+			name:        "returns error when id & client wid are empty",
+			req:         cdcexchange.WaitForWithdrawalRequest{},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.ID", Reason: "cannot be empty if req.ClientWid is empty"},
+		},
+		{
+			name: "returns error when min interval is less than 0",
+			req: cdcexchange.WaitForWithdrawalRequest{
+				ID:     "1",
+				Policy: cdcexchange.PollPolicy{MinInterval: -1},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Policy.MinInterval", Reason: "cannot be less than 0"},
+		},
+		{
+			name: "returns error when max interval is less than min interval",
+			req: cdcexchange.WaitForWithdrawalRequest{
+				ID:     "1",
+				Policy: cdcexchange.PollPolicy{MinInterval: time.Minute, MaxInterval: time.Second},
+			},
+			expectedErr: cdcerrors.InvalidParameterError{Parameter: "req.Policy.MaxInterval", Reason: "cannot be less than req.Policy.MinInterval"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cdcexchange.New("api key", "secret key")
+			require.NoError(t, err)
+
+			res, err := client.WaitForWithdrawal(context.Background(), tt.req)
+			require.Error(t, err)
+
+			assert.Nil(t, res)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestClient_WaitForWithdrawal(t *testing.T) {
+	now := time.Now().Round(time.Second)
+	withdrawal := func(id string, clientWid string, status cdcexchange.WithdrawalStatus, txid string) string {
+		return fmt.Sprintf(`{"currency":"BTC","client_wid":"%s","fee":"0.0005","create_time":%d,"id":"%s","update_time":%d,"amount":"1","address":"some address","status":"%s","txid":"%s"}`,
+			clientWid, now.UnixMilli(), id, now.UnixMilli(), string(status), txid)
+	}
+
+	t.Run("returns withdrawal once completed & emits each status change", func(t *testing.T) {
+		var (
+			clock = clockwork.NewFakeClockAt(now)
+			other = withdrawal("2", "", cdcexchange.WithdrawalStatusCompleted, "other txid")
+		)
+
+		s, requests := withdrawalHistoryServer(t,
+			// not yet in the history.
+			other,
+			withdrawal("1", "some client wid", cdcexchange.WithdrawalStatusPending, ""),
+			withdrawal("1", "some client wid", cdcexchange.WithdrawalStatusPending, ""),
+			other+","+withdrawal("1", "some client wid", cdcexchange.WithdrawalStatusProcessing, ""),
+			withdrawal("1", "some client wid", cdcexchange.WithdrawalStatusCompleted, "some txid"),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		req := cdcexchange.CreateWithdrawalResult{
+			Id:         1,
+			Symbol:     "BTC",
+			ClientWid:  "some client wid",
+			CreateTime: cdctime.Time(now),
+		}.WaitForWithdrawalRequest()
+		req.Policy = cdcexchange.PollPolicy{MinInterval: time.Second, MaxInterval: 2 * time.Second}
+
+		var statuses []cdcexchange.WithdrawalStatus
+		req.OnStatusChange = func(w cdcexchange.Withdrawal) {
+			statuses = append(statuses, w.Status)
+		}
+
+		type result struct {
+			res *cdcexchange.Withdrawal
+			err error
+		}
+		done := make(chan result)
+		go func() {
+			res, err := client.WaitForWithdrawal(context.Background(), req)
+			done <- result{res: res, err: err}
+		}()
+
+		for _, interval := range []time.Duration{time.Second, 2 * time.Second, 2 * time.Second, 2 * time.Second} {
+			clock.BlockUntil(1)
+			clock.Advance(interval)
+		}
+
+		select {
+		case r := <-done:
+			require.NoError(t, r.err)
+			assert.Equal(t, "1", r.res.Id)
+			assert.Equal(t, cdcexchange.WithdrawalStatusCompleted, r.res.Status)
+			assert.Equal(t, "some txid", r.res.Txid)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for withdrawal")
+		}
+
+		assert.Equal(t, []cdcexchange.WithdrawalStatus{
+			cdcexchange.WithdrawalStatusPending,
+			cdcexchange.WithdrawalStatusProcessing,
+			cdcexchange.WithdrawalStatusCompleted,
+		}, statuses)
+
+		reqs := requests()
+		require.Len(t, reqs, 5)
+		assert.Equal(t, "BTC", reqs[0].Params["currency"])
+		assert.Equal(t, float64(now.Add(-time.Minute).UnixMilli()), reqs[0].Params["start_ts"])
+		assert.Equal(t, float64(now.Add(-time.Minute).Add(24*time.Hour).UnixMilli()), reqs[0].Params["end_ts"])
+	})
+
+	t.Run("returns withdrawal failed error given terminal status", func(t *testing.T) {
+		s, _ := withdrawalHistoryServer(t,
+			withdrawal("1", "some client wid", cdcexchange.WithdrawalStatusRejected, ""),
+		)
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clockwork.NewFakeClockAt(now)),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		res, err := client.WaitForWithdrawal(context.Background(), cdcexchange.WaitForWithdrawalRequest{ClientWid: "some client wid"})
+		require.Error(t, err)
+
+		assert.Nil(t, res)
+
+		var failedErr cdcexchange.WithdrawalFailedError
+		require.True(t, errors.As(err, &failedErr))
+		assert.Equal(t, "1", failedErr.Withdrawal.Id)
+		assert.Equal(t, cdcexchange.WithdrawalStatusRejected, failedErr.Withdrawal.Status)
+	})
+
+	t.Run("keeps polling through retryable errors", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(now)
+		responses := []struct {
+			statusCode int
+			body       string
+		}{
+			{http.StatusInternalServerError, `{"id":0,"method":"","code":10001}`},
+			{http.StatusTooManyRequests, `{"id":0,"method":"","code":10006}`},
+			{http.StatusOK, okResponse(fmt.Sprintf(`{"withdrawal_list":[%s]}`,
+				withdrawal("1", "", cdcexchange.WithdrawalStatusCompleted, "some txid")))},
+		}
+		s, requests := apiServer(t, func(w http.ResponseWriter, r *http.Request, req api.Request, n int) string {
+			w.WriteHeader(responses[n].statusCode)
+			return responses[n].body
+		})
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		req := cdcexchange.WaitForWithdrawalRequest{
+			ID:     "1",
+			Policy: cdcexchange.PollPolicy{MinInterval: time.Second, MaxInterval: time.Second},
+		}
+		done := make(chan error)
+		go func() {
+			res, err := client.WaitForWithdrawal(context.Background(), req)
+			if err == nil {
+				assert.Equal(t, "some txid", res.Txid)
+			}
+			done <- err
+		}()
+
+		for i := 0; i < 2; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+		}
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for withdrawal")
+		}
+		assert.Len(t, requests(), 3)
+	})
+
+	t.Run("returns error once ctx is done", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(now)
+		s, _ := withdrawalHistoryServer(t, "")
+
+		client, err := cdcexchange.New("api key", "secret key",
+			cdcexchange.WithClock(clock),
+			cdcexchange.WithHTTPClient(s.Client()),
+			cdcexchange.WithBaseURL(fmt.Sprintf("%s/", s.URL)),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := client.WaitForWithdrawal(ctx, cdcexchange.WaitForWithdrawalRequest{ID: "1"})
+			done <- err
+		}()
+
+		clock.BlockUntil(1)
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.True(t, errors.Is(err, context.Canceled))
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for withdrawal")
+		}
+	})
+}